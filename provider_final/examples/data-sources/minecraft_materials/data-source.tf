@@ -0,0 +1,21 @@
+terraform {
+  required_providers {
+    minecraft = {
+      source  = "local/hashicraft/minecraft"
+      version = "0.1.0"
+    }
+  }
+}
+
+provider "minecraft" {
+  endpoint = "http://minecraft.container.shipyard.run:9090"
+  api_key = "supertopsecret"
+}
+
+data "minecraft_materials" "falling" {
+  tag = "gravity"
+}
+
+output "falling_blocks" {
+  value = data.minecraft_materials.falling.ids
+}
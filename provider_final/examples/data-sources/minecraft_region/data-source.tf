@@ -0,0 +1,30 @@
+terraform {
+  required_providers {
+    minecraft = {
+      source  = "local/hashicraft/minecraft"
+      version = "0.1.0"
+    }
+  }
+}
+
+provider "minecraft" {
+  endpoint = "http://minecraft.container.shipyard.run:9090"
+  api_key = "supertopsecret"
+}
+
+data "minecraft_region" "plot" {
+  start_x = -1278
+  start_y = 24
+  start_z = 288
+  end_x   = -1269
+  end_y   = 33
+  end_z   = 297
+}
+
+output "plot_is_empty" {
+  value = data.minecraft_region.plot.all_air
+}
+
+output "plot_materials" {
+  value = data.minecraft_region.plot.material_counts
+}
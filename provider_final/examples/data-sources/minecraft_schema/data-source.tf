@@ -0,0 +1,30 @@
+terraform {
+  required_providers {
+    minecraft = {
+      source  = "local/hashicraft/minecraft"
+      version = "0.1.0"
+    }
+  }
+}
+
+provider "minecraft" {
+  endpoint = "http://minecraft.container.shipyard.run:9090"
+  api_key = "supertopsecret"
+}
+
+variable "bus_id" {
+  description = "Undo identifier of the bus placed by another workspace"
+}
+
+data "minecraft_schema" "bus" {
+  id = var.bus_id
+}
+
+# place a car next to the bus
+resource "minecraft_schema" "car" {
+  x = data.minecraft_schema.bus.end_x + 2
+  y = data.minecraft_schema.bus.start_y
+  z = data.minecraft_schema.bus.start_z
+  rotation = 0
+  schema = "../../../schemas/car.zip"
+}
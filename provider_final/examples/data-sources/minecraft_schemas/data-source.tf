@@ -0,0 +1,19 @@
+terraform {
+  required_providers {
+    minecraft = {
+      source  = "local/hashicraft/minecraft"
+      version = "0.1.0"
+    }
+  }
+}
+
+provider "minecraft" {
+  endpoint = "http://minecraft.container.shipyard.run:9090"
+  api_key = "supertopsecret"
+}
+
+data "minecraft_schemas" "all" {}
+
+output "placed_schemas" {
+  value = [for s in data.minecraft_schemas.all.schemas : s.id]
+}
@@ -0,0 +1,3 @@
+output "plot" {
+  value = provider::minecraft::bounding_box({ x = -1278, y = 24, z = 288 }, { x = -1269, y = 33, z = 297 })
+}
@@ -0,0 +1,3 @@
+output "chunk" {
+  value = provider::minecraft::chunk_of({ x = -1272, y = 23, z = 288 })
+}
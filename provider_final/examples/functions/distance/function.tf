@@ -0,0 +1,3 @@
+output "distance" {
+  value = provider::minecraft::distance({ x = -1278, y = 24, z = 288 }, { x = -1269, y = 24, z = 297 })
+}
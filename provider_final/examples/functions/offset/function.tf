@@ -0,0 +1,4 @@
+# place a block three blocks above the origin
+output "above" {
+  value = provider::minecraft::offset({ x = -1272, y = 23, z = 288 }, 0, 3, 0)
+}
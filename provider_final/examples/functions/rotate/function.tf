@@ -0,0 +1,4 @@
+# position of a block in a structure placed with rotation = 90
+output "rotated" {
+  value = provider::minecraft::rotate({ x = -1270, y = 24, z = 290 }, { x = -1272, y = 24, z = 288 }, 90)
+}
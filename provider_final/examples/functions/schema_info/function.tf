@@ -0,0 +1,20 @@
+locals {
+  bus = provider::minecraft::schema_info("../../../schemas/bus.zip", 270)
+}
+
+# place the car two blocks beyond the end of the bus
+resource "minecraft_schema" "bus" {
+  x = -1278
+  y = 24
+  z = 288
+  rotation = 270
+  schema = "../../../schemas/bus.zip"
+}
+
+resource "minecraft_schema" "car" {
+  x = -1278 + local.bus.footprint.end.x + 2
+  y = 24
+  z = 288
+  rotation = 270
+  schema = "../../../schemas/car.zip"
+}
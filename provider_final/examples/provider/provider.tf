@@ -1,3 +1,38 @@
-provider "scaffolding" {
-  # example configuration here
+# Every attribute can also be set with a MINECRAFT_* environment variable,
+# e.g. MINECRAFT_ENDPOINT and MINECRAFT_APIKEY. Values set here take precedence.
+provider "minecraft" {
+  endpoint = "http://minecraft.container.shipyard.run:9090"
+  api_key  = "supertopsecret"
+
+  request_timeout   = "30s"
+  max_retries       = 4
+  user_agent_suffix = "ci"
+
+  # keep large applies from lagging the server tick
+  requests_per_second     = 20
+  max_concurrent_requests = 4
+}
+
+# A server behind a reverse proxy that requires client certificates.
+provider "minecraft" {
+  alias    = "mtls"
+  endpoint = "https://minecraft.example.com"
+  api_key  = "supertopsecret"
+
+  ca_cert_file     = "ca.pem"
+  client_cert_file = "client.pem"
+  client_key_file  = "client-key.pem"
+  tls_min_version  = "1.3"
+}
+
+# A bearer token that is rotated by an external process, the file is read
+# again when the server rejects the token.
+provider "minecraft" {
+  alias    = "token"
+  endpoint = "https://minecraft.example.com"
+
+  auth {
+    type       = "bearer"
+    token_file = "/var/run/secrets/minecraft/token"
+  }
 }
@@ -0,0 +1,29 @@
+terraform {
+  required_providers {
+    minecraft = {
+      source  = "local/hashicraft/minecraft"
+      version = "0.1.0"
+    }
+  }
+}
+
+provider "minecraft" {
+  endpoint = "http://minecraft.container.shipyard.run:9090"
+  api_key = "supertopsecret"
+}
+
+resource "minecraft_block" "stone" {
+  x = -1272
+  y = 23
+  z = 288
+  material = "minecraft:stone"
+}
+
+# The same coordinates in the nether are a different block
+resource "minecraft_block" "netherrack" {
+  x = -1272
+  y = 23
+  z = 288
+  material = "minecraft:netherrack"
+  world = "minecraft:the_nether"
+}
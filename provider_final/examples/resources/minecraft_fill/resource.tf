@@ -0,0 +1,24 @@
+terraform {
+  required_providers {
+    minecraft = {
+      source  = "local/hashicraft/minecraft"
+      version = "0.1.0"
+    }
+  }
+}
+
+provider "minecraft" {
+  endpoint = "http://minecraft.container.shipyard.run:9090"
+  api_key = "supertopsecret"
+}
+
+resource "minecraft_fill" "room" {
+  start_x = -1280
+  start_y = 23
+  start_z = 280
+  end_x = -1274
+  end_y = 27
+  end_z = 286
+  material = "minecraft:oak_planks"
+  mode = "hollow"
+}
@@ -18,4 +18,13 @@ resource "minecraft_schema" "bus" {
   z = 288
   rotation = 270
   schema = "../../../schemas/car.zip"
-}
\ No newline at end of file
+}
+
+resource "minecraft_schema" "remote_bus" {
+  x = -1278
+  y = 24
+  z = 300
+  rotation = 270
+  schema_url = "https://example.com/schemas/car.zip"
+  schema_sha256 = "1a571b2314beb411ac55591d72d987928ce8e34111d2825f93392f3b104bbc60"
+}
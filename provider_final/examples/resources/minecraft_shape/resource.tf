@@ -0,0 +1,22 @@
+terraform {
+  required_providers {
+    minecraft = {
+      source  = "local/hashicraft/minecraft"
+      version = "0.1.0"
+    }
+  }
+}
+
+provider "minecraft" {
+  endpoint = "http://minecraft.container.shipyard.run:9090"
+  api_key = "supertopsecret"
+}
+
+resource "minecraft_shape" "shape" {
+  x = -1278
+  y = 24
+  z = 290
+  rotation = 90
+  shape_file = "../../../../jumppad/shape.json"
+  skip_air = true
+}
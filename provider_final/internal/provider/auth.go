@@ -0,0 +1,149 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+// Values for the type attribute of the provider auth block, they select how
+// the credential is sent to the server.
+const (
+	authTypeAPIKey = "api_key"
+	authTypeBearer = "bearer"
+)
+
+// authHeader is the header used to send the credential for authTypeAPIKey.
+const authHeader = "X-API-Key"
+
+// tokenSource loads a credential, e.g. from a file or the output of a
+// command.
+type tokenSource func(ctx context.Context) (string, error)
+
+// authenticator adds a credential to API requests. A static credential is
+// never reloaded, a credential read from a source is loaded on first use and
+// reloaded when the server rejects it so that credentials rotated during an
+// apply are picked up.
+type authenticator struct {
+	authType string
+	source   tokenSource
+
+	mu    sync.Mutex
+	token string
+}
+
+// newStaticAuth returns an authenticator that always sends token.
+func newStaticAuth(authType, token string) *authenticator {
+	return &authenticator{authType: authType, token: token}
+}
+
+// newSourceAuth returns an authenticator that sends the token loaded from
+// source.
+func newSourceAuth(authType string, source tokenSource) *authenticator {
+	return &authenticator{authType: authType, source: source}
+}
+
+// credential returns the token to send, loading it from the source on first
+// use.
+func (a *authenticator) credential(ctx context.Context) (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.token != "" || a.source == nil {
+		return a.token, nil
+	}
+
+	token, err := a.load(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	a.token = token
+
+	return token, nil
+}
+
+// refresh reloads the token after the server rejected rejected, it returns
+// true when the token has changed and the request should be sent again.
+func (a *authenticator) refresh(ctx context.Context, rejected string) (bool, error) {
+	if a.source == nil {
+		return false, nil
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	// another request may have reloaded the token already
+	if a.token != rejected {
+		return true, nil
+	}
+
+	token, err := a.load(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	a.token = token
+
+	return token != rejected, nil
+}
+
+func (a *authenticator) load(ctx context.Context) (string, error) {
+	token, err := a.source(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return "", errors.New("the credential source returned an empty token")
+	}
+
+	return token, nil
+}
+
+// apply adds token to the request.
+func (a *authenticator) apply(r *http.Request, token string) {
+	switch a.authType {
+	case authTypeBearer:
+		r.Header.Set("Authorization", "Bearer "+token)
+	default:
+		r.Header.Set(authHeader, token)
+	}
+}
+
+// fileTokenSource reads the token from the file at path.
+func fileTokenSource(path string) tokenSource {
+	return func(ctx context.Context) (string, error) {
+		d, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("unable to read token file: %s", err)
+		}
+
+		return string(d), nil
+	}
+}
+
+// commandTokenSource runs the command in args and uses its output as the
+// token.
+func commandTokenSource(args []string) tokenSource {
+	return func(ctx context.Context) (string, error) {
+		var stdout, stderr bytes.Buffer
+
+		cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+
+		if err := cmd.Run(); err != nil {
+			return "", fmt.Errorf("unable to run token command %q: %s: %s", args[0], err, strings.TrimSpace(stderr.String()))
+		}
+
+		return stdout.String(), nil
+	}
+}
@@ -0,0 +1,176 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// rotatingServer accepts a single bearer token which can be rotated by the
+// test, it records the body of every accepted request.
+type rotatingServer struct {
+	*httptest.Server
+
+	mu     sync.Mutex
+	token  string
+	bodies []string
+	calls  int32
+}
+
+func newRotatingServer(t *testing.T, token string) *rotatingServer {
+	t.Helper()
+
+	s := &rotatingServer{token: token}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&s.calls, 1)
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if r.Header.Get("Authorization") != "Bearer "+s.token {
+			http.Error(w, "invalid token", http.StatusUnauthorized)
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		s.bodies = append(s.bodies, string(body))
+
+		w.Write([]byte(`{"id":"1","x":1,"y":2,"z":3,"material":"minecraft:stone"}`))
+	}))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func (s *rotatingServer) rotate(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.token = token
+}
+
+func TestAuthAPIKey(t *testing.T) {
+	srv := newFakeMinecraftServer(t)
+
+	opts := testClientOptions()
+	opts.Auth = newStaticAuth(authTypeAPIKey, fakeAPIKey)
+
+	c := newClient(srv.URL, "", opts)
+	if _, err := c.getBlock(context.Background(), defaultWorld, 0, 0, 0); err != nil {
+		t.Fatalf("expected the API key to be accepted, got: %s", err)
+	}
+}
+
+func TestAuthBearer(t *testing.T) {
+	srv := newRotatingServer(t, "secret")
+
+	opts := testClientOptions()
+	opts.Auth = newStaticAuth(authTypeBearer, "secret")
+
+	c := newClient(srv.URL, "", opts)
+	if _, err := c.getBlock(context.Background(), defaultWorld, 1, 2, 3); err != nil {
+		t.Fatalf("expected the bearer token to be accepted, got: %s", err)
+	}
+
+	// a static token is not reloaded
+	srv.rotate("rotated")
+
+	if _, err := c.getBlock(context.Background(), defaultWorld, 1, 2, 3); !IsUnauthorized(err) {
+		t.Fatalf("expected an unauthorized error, got: %v", err)
+	}
+
+	if calls := atomic.LoadInt32(&srv.calls); calls != 2 {
+		t.Fatalf("expected 2 calls, got: %d", calls)
+	}
+}
+
+func TestAuthTokenFileReloadedOnUnauthorized(t *testing.T) {
+	srv := newRotatingServer(t, "first")
+
+	file := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(file, []byte("first\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := testClientOptions()
+	opts.Auth = newSourceAuth(authTypeBearer, fileTokenSource(file))
+
+	c := newClient(srv.URL, "", opts)
+	ctx := context.Background()
+
+	if _, err := c.getBlock(ctx, defaultWorld, 1, 2, 3); err != nil {
+		t.Fatalf("expected the token from the file to be accepted, got: %s", err)
+	}
+
+	// the credentials are rotated during the apply
+	srv.rotate("second")
+	if err := os.WriteFile(file, []byte("second\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.createBlock(ctx, defaultWorld, blockRequest{X: 1, Y: 2, Z: 3, Material: "minecraft:stone"}); err != nil {
+		t.Fatalf("expected the rotated token to be used, got: %s", err)
+	}
+
+	if len(srv.bodies) != 2 || srv.bodies[1] == "" {
+		t.Fatalf("expected the body to be sent again, got: %q", srv.bodies)
+	}
+
+	// a token that is rejected after it was reloaded is reported
+	srv.rotate("third")
+
+	if _, err := c.getBlock(ctx, defaultWorld, 1, 2, 3); !IsUnauthorized(err) {
+		t.Fatalf("expected an unauthorized error, got: %v", err)
+	}
+
+	if calls := atomic.LoadInt32(&srv.calls); calls != 4 {
+		t.Fatalf("expected 4 calls, got: %d", calls)
+	}
+}
+
+func TestAuthTokenCommand(t *testing.T) {
+	srv := newRotatingServer(t, "from-command")
+
+	var diags diag.Diagnostics
+	c := newProviderClient(MinecraftProviderModel{
+		Endpoint: types.StringValue(srv.URL),
+		Auth: &AuthModel{
+			Type:         types.StringValue(authTypeBearer),
+			TokenCommand: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("echo"), types.StringValue("from-command")}),
+		},
+	}, "test", &diags)
+	if diags.HasError() {
+		t.Fatalf("expected no errors, got: %v", diags)
+	}
+
+	if _, err := c.getBlock(context.Background(), defaultWorld, 1, 2, 3); err != nil {
+		t.Fatalf("expected the token from the command to be accepted, got: %s", err)
+	}
+}
+
+func TestAuthSourceErrors(t *testing.T) {
+	opts := testClientOptions()
+	opts.Auth = newSourceAuth(authTypeBearer, fileTokenSource(filepath.Join(t.TempDir(), "missing")))
+
+	c := newClient("http://localhost", "", opts)
+	if _, err := c.getBlock(context.Background(), defaultWorld, 1, 2, 3); err == nil {
+		t.Fatal("expected an error reading the token file")
+	}
+
+	opts.Auth = newSourceAuth(authTypeBearer, commandTokenSource([]string{"true"}))
+
+	c = newClient("http://localhost", "", opts)
+	if _, err := c.getBlock(context.Background(), defaultWorld, 1, 2, 3); err == nil {
+		t.Fatal("expected an error for an empty token")
+	}
+}
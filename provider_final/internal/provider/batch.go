@@ -0,0 +1,350 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	// defaultBatchWindow is how long a queued block operation waits for
+	// operations from other resources before the batch is sent.
+	defaultBatchWindow = 10 * time.Millisecond
+
+	// maxBatchSize is the maximum number of blocks sent in a single batch
+	// request.
+	maxBatchSize = 100
+)
+
+// blockPosition identifies a block removed by a batch delete request.
+type blockPosition struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+	Z int `json:"z"`
+}
+
+// blockBatchItem is the outcome of a single block of a batch request, the
+// server returns one item for each block in the order of the request.
+type blockBatchItem struct {
+	Status int            `json:"status"`
+	Error  string         `json:"error,omitempty"`
+	Block  *blockResponse `json:"block,omitempty"`
+}
+
+// blockResult is the outcome of a single block of a batch operation, Block is
+// nil for deleted blocks.
+type blockResult struct {
+	Block *blockResponse
+	Err   error
+}
+
+// createBlocks places the blocks in world using as few requests as possible
+// and returns the result of each block, in order. A failed request fails
+// every block it contained, other blocks are still placed.
+func (c *client) createBlocks(ctx context.Context, world string, blocks []blockRequest) []blockResult {
+	results := c.batchBlocks(ctx, newRoute(world, "/v1/blocks"), blocks, func(chunk []blockRequest) any {
+		return chunk
+	}, func(ctx context.Context, b blockRequest) (*blockResponse, error) {
+		return c.createBlock(ctx, world, b)
+	})
+
+	for i, r := range results {
+		if r.Err == nil && r.Block == nil {
+			results[i].Err = fmt.Errorf("unable to decode block: block %s is missing from the response", blockCoordinate(blocks[i]))
+		}
+	}
+
+	return results
+}
+
+// deleteBlocks removes the blocks in world using as few requests as possible
+// and returns the result of each block, in order. Blocks that do not exist
+// fail with a not found error.
+func (c *client) deleteBlocks(ctx context.Context, world string, blocks []blockRequest) []blockResult {
+	return c.batchBlocks(ctx, newRoute(world, "/v1/blocks/delete"), blocks, func(chunk []blockRequest) any {
+		positions := make([]blockPosition, len(chunk))
+		for i, b := range chunk {
+			positions[i] = blockPosition{b.X, b.Y, b.Z}
+		}
+
+		return positions
+	}, func(ctx context.Context, b blockRequest) (*blockResponse, error) {
+		return nil, c.deleteBlock(ctx, world, b)
+	})
+}
+
+// placeBlocks sets the blocks to their material using as few requests as
+// possible and returns the result of each block, in order. Air is placed by
+// deleting the block, so blocks that no longer exist fail with a not found
+// error.
+func (c *client) placeBlocks(ctx context.Context, world string, blocks []blockRequest) []blockResult {
+	var create, remove []blockRequest
+	var createIndex, removeIndex []int
+
+	for i, b := range blocks {
+		if b.Material == "" || b.Material == airMaterial {
+			remove = append(remove, b)
+			removeIndex = append(removeIndex, i)
+			continue
+		}
+
+		create = append(create, b)
+		createIndex = append(createIndex, i)
+	}
+
+	results := make([]blockResult, len(blocks))
+
+	if len(create) > 0 {
+		for i, r := range c.createBlocks(ctx, world, create) {
+			results[createIndex[i]] = r
+		}
+	}
+
+	if len(remove) > 0 {
+		for i, r := range c.deleteBlocks(ctx, world, remove) {
+			results[removeIndex[i]] = r
+		}
+	}
+
+	return results
+}
+
+// batchBlocks sends the blocks to the batch route in chunks of up to
+// maxBatchSize, payload returns the request body for a chunk. When the
+// server does not support the batch endpoints each block is sent with
+// single, using up to defaultRegionWorkers concurrent requests.
+func (c *client) batchBlocks(ctx context.Context, route apiRoute, blocks []blockRequest, payload func([]blockRequest) any, single func(context.Context, blockRequest) (*blockResponse, error)) []blockResult {
+	results := make([]blockResult, len(blocks))
+	unsupported := c.batchUnsupported.Load()
+
+	for start := 0; start < len(blocks) && !unsupported; start += maxBatchSize {
+		end := min(start+maxBatchSize, len(blocks))
+		chunk := blocks[start:end]
+
+		d, err := json.Marshal(payload(chunk))
+		if err != nil {
+			failBlocks(results[start:end], fmt.Errorf("unable to marshal blocks to json: %s", err))
+			continue
+		}
+
+		r := route
+		r.attrs = append(r.attrs, attribute.Int("minecraft.blocks", len(chunk)))
+
+		body, err := c.do(ctx, http.MethodPost, r, bytes.NewReader(d), "")
+		switch {
+		case err == nil:
+			decodeBlockResults(results[start:end], r, chunk, body)
+		case start == 0 && (IsNotFound(err) || hasStatus(err, http.StatusMethodNotAllowed)):
+			// the server does not support the batch endpoints
+			c.batchUnsupported.Store(true)
+			unsupported = true
+		default:
+			failBlocks(results[start:end], err)
+		}
+	}
+
+	if !unsupported {
+		return results
+	}
+
+	var wg sync.WaitGroup
+	workers := make(chan struct{}, defaultRegionWorkers)
+
+	for i, b := range blocks {
+		wg.Add(1)
+		workers <- struct{}{}
+
+		go func(i int, b blockRequest) {
+			defer wg.Done()
+			defer func() { <-workers }()
+
+			block, err := single(ctx, b)
+			results[i] = blockResult{Block: block, Err: err}
+		}(i, b)
+	}
+
+	wg.Wait()
+
+	return results
+}
+
+// decodeBlockResults sets results from the items in the response body of a
+// batch request for blocks.
+func decodeBlockResults(results []blockResult, route apiRoute, blocks []blockRequest, body []byte) {
+	items := []blockBatchItem{}
+	if err := json.Unmarshal(body, &items); err != nil {
+		failBlocks(results, fmt.Errorf("unable to decode blocks: %s", err))
+		return
+	}
+
+	if len(items) != len(blocks) {
+		failBlocks(results, fmt.Errorf("unable to decode blocks: expected %d results, got %d", len(blocks), len(items)))
+		return
+	}
+
+	for i, item := range items {
+		if item.Status != http.StatusOK {
+			results[i].Err = &APIError{
+				StatusCode: item.Status,
+				Method:     http.MethodPost,
+				Endpoint:   route.path,
+				Message:    fmt.Sprintf("block %s: %s", blockCoordinate(blocks[i]), item.Error),
+			}
+
+			continue
+		}
+
+		results[i].Block = item.Block
+	}
+}
+
+func failBlocks(results []blockResult, err error) {
+	for i := range results {
+		results[i] = blockResult{Err: err}
+	}
+}
+
+func blockCoordinate(b blockRequest) coordinate {
+	return coordinate{b.X, b.Y, b.Z}
+}
+
+// queueCreateBlock places a block like createBlock, blocks queued by
+// concurrent resources are sent together in a single batch request.
+func (c *client) queueCreateBlock(ctx context.Context, world string, block blockRequest) (*blockResponse, error) {
+	if c.batchUnsupported.Load() {
+		return c.createBlock(ctx, world, block)
+	}
+
+	r := c.batcher.submit(ctx, batchCreate, world, block)
+	return r.Block, r.Err
+}
+
+// queueDeleteBlock removes a block like deleteBlock, blocks queued by
+// concurrent resources are sent together in a single batch request.
+func (c *client) queueDeleteBlock(ctx context.Context, world string, block blockRequest) error {
+	if c.batchUnsupported.Load() {
+		return c.deleteBlock(ctx, world, block)
+	}
+
+	return c.batcher.submit(ctx, batchDelete, world, block).Err
+}
+
+const (
+	batchCreate = "create"
+	batchDelete = "delete"
+)
+
+// blockBatcher coalesces the block operations queued by resources within a
+// short window into batch requests. Terraform applies resources in parallel,
+// so a configuration with many blocks is placed with a few requests.
+type blockBatcher struct {
+	client *client
+	window time.Duration
+
+	mu      sync.Mutex
+	pending map[batchKey]*pendingBatch
+}
+
+// batchKey groups the operations that can be sent in the same request.
+type batchKey struct {
+	op    string
+	world string
+}
+
+// pendingBatch collects the blocks of a batch until it is sent, done is
+// closed once results is set.
+type pendingBatch struct {
+	ctx     context.Context
+	blocks  []blockRequest
+	results []blockResult
+	done    chan struct{}
+}
+
+func newBlockBatcher(c *client, window time.Duration) *blockBatcher {
+	return &blockBatcher{
+		client:  c,
+		window:  window,
+		pending: map[batchKey]*pendingBatch{},
+	}
+}
+
+// submit adds the block to the pending batch for the operation and world and
+// waits for its result. The batch is sent when the window of its first block
+// has passed or it is full. The request uses the context of the first block
+// without its cancellation, so a cancelled resource does not fail the other
+// blocks of the batch.
+func (b *blockBatcher) submit(ctx context.Context, op, world string, block blockRequest) blockResult {
+	key := batchKey{op, world}
+
+	b.mu.Lock()
+
+	p, ok := b.pending[key]
+	if !ok {
+		p = &pendingBatch{ctx: context.WithoutCancel(ctx), done: make(chan struct{})}
+		b.pending[key] = p
+
+		time.AfterFunc(b.window, func() { b.flush(key, p) })
+	}
+
+	i := len(p.blocks)
+	p.blocks = append(p.blocks, block)
+
+	if len(p.blocks) >= maxBatchSize {
+		delete(b.pending, key)
+		go b.send(key, p)
+	}
+
+	b.mu.Unlock()
+
+	select {
+	case <-p.done:
+		return p.results[i]
+	case <-ctx.Done():
+		return blockResult{Err: fmt.Errorf("request cancelled while waiting for the batch: %w", ctx.Err())}
+	}
+}
+
+// flush sends the batch unless it has already been sent because it was full.
+func (b *blockBatcher) flush(key batchKey, p *pendingBatch) {
+	b.mu.Lock()
+
+	if b.pending[key] != p {
+		b.mu.Unlock()
+		return
+	}
+
+	delete(b.pending, key)
+	b.mu.Unlock()
+
+	b.send(key, p)
+}
+
+// send sends the batch, a batch with a single block uses the single block
+// endpoints.
+func (b *blockBatcher) send(key batchKey, p *pendingBatch) {
+	defer close(p.done)
+
+	if len(p.blocks) == 1 {
+		var r blockResult
+		if key.op == batchCreate {
+			r.Block, r.Err = b.client.createBlock(p.ctx, key.world, p.blocks[0])
+		} else {
+			r.Err = b.client.deleteBlock(p.ctx, key.world, p.blocks[0])
+		}
+
+		p.results = []blockResult{r}
+		return
+	}
+
+	if key.op == batchCreate {
+		p.results = b.client.createBlocks(p.ctx, key.world, p.blocks)
+	} else {
+		p.results = b.client.deleteBlocks(p.ctx, key.world, p.blocks)
+	}
+}
@@ -0,0 +1,215 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestClientCreateBlocks(t *testing.T) {
+	srv := newFakeMinecraftServer(t)
+	c := newClient(srv.URL, fakeAPIKey, testClientOptions())
+	ctx := context.Background()
+
+	blocks := []blockRequest{
+		{X: 1, Y: 2, Z: 3, Material: "minecraft:stone"},
+		{X: 1, Y: 3, Z: 3},
+		{X: 1, Y: 4, Z: 3, Material: "minecraft:dirt"},
+	}
+
+	results := c.createBlocks(ctx, defaultWorld, blocks)
+
+	if results[0].Err != nil || results[0].Block.Material != "minecraft:stone" || results[2].Err != nil {
+		t.Fatalf("expected the valid blocks to be placed, got: %+v", results)
+	}
+
+	// a failed block does not fail the rest of the batch
+	if !hasStatus(results[1].Err, http.StatusBadRequest) || !strings.Contains(results[1].Err.Error(), "block 1,3,3: material is required") {
+		t.Fatalf("expected the invalid block to fail, got: %v", results[1].Err)
+	}
+
+	if srv.material(1, 4, 3) != "minecraft:dirt" {
+		t.Fatal("expected the block after the failed block to be placed")
+	}
+
+	if n := srv.requestCount(http.MethodPost, "/v1/blocks"); n != 1 {
+		t.Fatalf("expected a single batch request, got: %d", n)
+	}
+
+	results = c.deleteBlocks(ctx, defaultWorld, blocks)
+	for _, r := range results {
+		if r.Err != nil {
+			t.Fatalf("expected no error deleting blocks, got: %s", r.Err)
+		}
+	}
+
+	if srv.material(1, 2, 3) != fakeAirMaterial {
+		t.Fatal("expected the block to be deleted")
+	}
+}
+
+func TestClientCreateBlocksChunks(t *testing.T) {
+	srv := newFakeMinecraftServer(t)
+	c := newClient(srv.URL, fakeAPIKey, testClientOptions())
+
+	blocks := make([]blockRequest, maxBatchSize+1)
+	for i := range blocks {
+		blocks[i] = blockRequest{X: i, Material: "minecraft:stone"}
+	}
+
+	for _, r := range c.createBlocks(context.Background(), defaultWorld, blocks) {
+		if r.Err != nil {
+			t.Fatal(r.Err)
+		}
+	}
+
+	if n := srv.requestCount(http.MethodPost, "/v1/blocks"); n != 2 {
+		t.Fatalf("expected 2 batch requests, got: %d", n)
+	}
+
+	if srv.material(maxBatchSize, 0, 0) != "minecraft:stone" {
+		t.Fatal("expected the last block to be placed")
+	}
+}
+
+func TestClientCreateBlocksFallback(t *testing.T) {
+	srv := newFakeMinecraftServer(t)
+	srv.failNext(http.MethodPost, "/v1/blocks", http.StatusNotFound, 1)
+
+	c := newClient(srv.URL, fakeAPIKey, testClientOptions())
+	ctx := context.Background()
+
+	blocks := []blockRequest{
+		{X: 1, Y: 2, Z: 3, Material: "minecraft:stone"},
+		{X: 1, Y: 3, Z: 3},
+	}
+
+	results := c.createBlocks(ctx, defaultWorld, blocks)
+
+	if !c.batchUnsupported.Load() {
+		t.Fatal("expected the batch endpoint to be marked as unsupported")
+	}
+
+	if results[0].Err != nil || srv.material(1, 2, 3) != "minecraft:stone" {
+		t.Fatalf("expected the block to be placed individually, got: %v", results[0].Err)
+	}
+
+	if !hasStatus(results[1].Err, http.StatusBadRequest) {
+		t.Fatalf("expected the invalid block to fail, got: %v", results[1].Err)
+	}
+
+	if n := srv.requestCount(http.MethodPost, "/v1/block"); n != 2 {
+		t.Fatalf("expected a request for each block, got: %d", n)
+	}
+
+	// queued blocks are sent individually once the batch endpoint is unsupported
+	if err := c.queueDeleteBlock(ctx, defaultWorld, blocks[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	if srv.requestCount(http.MethodPost, "/v1/blocks/delete") != 0 || srv.material(1, 2, 3) != fakeAirMaterial {
+		t.Fatal("expected the block to be deleted individually")
+	}
+}
+
+func TestClientPlaceBlocks(t *testing.T) {
+	srv := newFakeMinecraftServer(t)
+	srv.setBlock(1, 3, 3, "minecraft:dirt")
+
+	c := newClient(srv.URL, fakeAPIKey, testClientOptions())
+	ctx := context.Background()
+
+	blocks := []blockRequest{
+		{X: 1, Y: 2, Z: 3, Material: "minecraft:stone"},
+		{X: 1, Y: 3, Z: 3, Material: airMaterial},
+		{X: 1, Y: 4, Z: 3, Material: "minecraft:glass"},
+	}
+
+	results := c.placeBlocks(ctx, defaultWorld, blocks)
+
+	for i, r := range results {
+		if r.Err != nil {
+			t.Fatalf("expected block %d to be placed, got: %s", i, r.Err)
+		}
+	}
+
+	if results[0].Block == nil || results[0].Block.Material != "minecraft:stone" || results[1].Block != nil {
+		t.Fatalf("expected the results in the order of the blocks, got: %v", results)
+	}
+
+	if srv.material(1, 3, 3) != fakeAirMaterial || srv.material(1, 4, 3) != "minecraft:glass" {
+		t.Fatal("expected the blocks to be placed")
+	}
+
+	if srv.requestCount(http.MethodPost, "/v1/blocks") != 1 || srv.requestCount(http.MethodPost, "/v1/blocks/delete") != 1 {
+		t.Fatal("expected a batch request for the placed and the removed blocks")
+	}
+}
+
+func TestClientQueueBlocks(t *testing.T) {
+	srv := newFakeMinecraftServer(t)
+	c := newClient(srv.URL, fakeAPIKey, testClientOptions())
+	ctx := context.Background()
+
+	const n = 20
+
+	errs := make([]error, n)
+	var wg sync.WaitGroup
+
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			br := blockRequest{X: i, Material: "minecraft:stone"}
+			if i == 7 {
+				br.Material = ""
+			}
+
+			block, err := c.queueCreateBlock(ctx, defaultWorld, br)
+			if err == nil && block.X != i {
+				t.Errorf("expected block %d, got: %d", i, block.X)
+			}
+
+			errs[i] = err
+		}(i)
+	}
+
+	wg.Wait()
+
+	// each resource receives the result of its own block
+	for i, err := range errs {
+		if (err != nil) != (i == 7) {
+			t.Fatalf("unexpected result for block %d: %v", i, err)
+		}
+	}
+
+	batches := srv.requestCount(http.MethodPost, "/v1/blocks")
+	if batches == 0 || batches+srv.requestCount(http.MethodPost, "/v1/block") >= n {
+		t.Fatalf("expected the blocks to be coalesced, got %d batch requests", batches)
+	}
+
+	// a block queued on its own uses the single block endpoint
+	if err := c.queueDeleteBlock(ctx, defaultWorld, blockRequest{X: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	if srv.requestCount(http.MethodDelete, "/v1/block/1/0/0") != 1 || srv.material(1, 0, 0) != fakeAirMaterial {
+		t.Fatal("expected the block to be deleted with the single block endpoint")
+	}
+}
+
+func TestClientQueueBlockCancelled(t *testing.T) {
+	srv := newFakeMinecraftServer(t)
+	c := newClient(srv.URL, fakeAPIKey, testClientOptions())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := c.queueCreateBlock(ctx, defaultWorld, blockRequest{Material: "minecraft:stone"}); err == nil {
+		t.Fatal("expected an error for a cancelled context")
+	}
+}
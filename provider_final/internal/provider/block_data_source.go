@@ -9,8 +9,10 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/telemetry"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -31,6 +33,8 @@ type BlockDataSourceModel struct {
 	Y        types.Number `tfsdk:"y"`
 	Z        types.Number `tfsdk:"z"`
 	Material types.String `tfsdk:"material"`
+	State    types.Map    `tfsdk:"state"`
+	World    types.String `tfsdk:"world"`
 	Id       types.String `tfsdk:"id"`
 }
 
@@ -60,6 +64,19 @@ func (d *BlockDataSource) Schema(ctx context.Context, req datasource.SchemaReque
 				MarkdownDescription: "Block configurable attribute",
 				Computed:            true,
 			},
+			"state": schema.MapAttribute{
+				MarkdownDescription: "Block state properties such as `facing`, `half` or `axis`",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"world": schema.StringAttribute{
+				MarkdownDescription: "World to read the block from, e.g. `minecraft:the_nether`. Defaults to the provider `world`.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					worldValidator{},
+				},
+			},
 			"id": schema.StringAttribute{
 				MarkdownDescription: "Block identifier",
 				Computed:            true,
@@ -89,6 +106,9 @@ func (d *BlockDataSource) Configure(ctx context.Context, req datasource.Configur
 }
 
 func (d *BlockDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := telemetry.Start(ctx, "data.minecraft_block.Read")
+	defer endOperation(span, &resp.Diagnostics)
+
 	var data BlockDataSourceModel
 
 	// Read Terraform configuration data into the model
@@ -102,17 +122,20 @@ func (d *BlockDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	y, _ := data.Y.ValueBigFloat().Int64()
 	z, _ := data.Z.ValueBigFloat().Int64()
 
-	block, err := d.minecraftClient.getBlock(int(x), int(y), int(z))
+	data.World = d.minecraftClient.resolveWorld(data.World)
+
+	block, err := d.minecraftClient.getBlock(ctx, data.World.ValueString(), int(x), int(y), int(z))
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to retrieve block",
-			fmt.Sprintf("Unable to get block, got error: %s", err),
-		)
+		addClientError(&resp.Diagnostics, "read block", err)
 		return
 	}
 
 	data.Material = types.StringValue(block.Material)
-	data.Id = types.StringValue(block.ID)
+	data.Id = types.StringValue(worldID(data.World.ValueString(), block.ID))
+
+	state, diags := blockStateToMap(ctx, block.blockState(), data.State)
+	resp.Diagnostics.Append(diags...)
+	data.State = state
 
 	// Write logs using the tflog package
 	// Documentation: https://terraform.io/plugin/log
@@ -0,0 +1,329 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/numberplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/telemetry"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &BlockResource{}
+var _ resource.ResourceWithImportState = &BlockResource{}
+var _ resource.ResourceWithModifyPlan = &BlockResource{}
+
+func NewBlockResource() resource.Resource {
+	return &BlockResource{}
+}
+
+// BlockResource defines the resource implementation.
+type BlockResource struct {
+	minecraftClient *client
+}
+
+// BlockResourceModel describes the resource data model.
+type BlockResourceModel struct {
+	X        types.Number `tfsdk:"x"`
+	Y        types.Number `tfsdk:"y"`
+	Z        types.Number `tfsdk:"z"`
+	Material types.String `tfsdk:"material"`
+	State    types.Map    `tfsdk:"state"`
+	World    types.String `tfsdk:"world"`
+	Id       types.String `tfsdk:"id"`
+}
+
+func (r *BlockResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_block"
+}
+
+func (r *BlockResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Places a single block in the Minecraft world",
+
+		Attributes: map[string]schema.Attribute{
+			"x": schema.NumberAttribute{
+				MarkdownDescription: "X coordinate of the block",
+				Required:            true,
+				PlanModifiers: []planmodifier.Number{
+					numberplanmodifier.RequiresReplace(),
+				},
+			},
+			"y": schema.NumberAttribute{
+				MarkdownDescription: "Y coordinate of the block",
+				Required:            true,
+				PlanModifiers: []planmodifier.Number{
+					numberplanmodifier.RequiresReplace(),
+				},
+			},
+			"z": schema.NumberAttribute{
+				MarkdownDescription: "Z coordinate of the block",
+				Required:            true,
+				PlanModifiers: []planmodifier.Number{
+					numberplanmodifier.RequiresReplace(),
+				},
+			},
+			"material": schema.StringAttribute{
+				MarkdownDescription: "Material of the block, e.g. `minecraft:stone`",
+				Required:            true,
+				Validators: []validator.String{
+					materialValidator{},
+				},
+			},
+			"state": schema.MapAttribute{
+				MarkdownDescription: "Block state properties such as `facing`, `half`, `axis` or `waterlogged`",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.Map{
+					blockStateValidator{},
+				},
+			},
+			"world": schema.StringAttribute{
+				MarkdownDescription: "World the block is placed in, e.g. `minecraft:the_nether`. Defaults to the provider `world`, changing it replaces the block.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					worldValidator{},
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Block identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *BlockResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.minecraftClient = client
+}
+
+func (r *BlockResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := telemetry.Start(ctx, "minecraft_block.Create")
+	defer endOperation(span, &resp.Diagnostics)
+
+	var data BlockResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	br, diags := data.blockRequest(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.World = r.minecraftClient.resolveWorld(data.World)
+
+	block, err := r.minecraftClient.queueCreateBlock(ctx, data.World.ValueString(), br)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "create block", err)
+		return
+	}
+
+	data.Id = types.StringValue(worldID(data.World.ValueString(), block.ID))
+
+	tflog.Trace(ctx, "created a block")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BlockResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := telemetry.Start(ctx, "minecraft_block.Read")
+	defer endOperation(span, &resp.Diagnostics)
+
+	var data BlockResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.World = types.StringValue(worldValue(data.World))
+
+	block, err := r.minecraftClient.getBlock(ctx, data.World.ValueString(), intValue(data.X), intValue(data.Y), intValue(data.Z))
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read block", err)
+		return
+	}
+
+	// if the block has been mined or replaced in game the material will
+	// differ from state, recording the new value lets Terraform plan a fix
+	if block.Material != data.Material.ValueString() {
+		tflog.Debug(ctx, "block material has drifted", map[string]interface{}{
+			"expected": data.Material.ValueString(),
+			"actual":   block.Material,
+		})
+	}
+
+	data.Material = types.StringValue(block.Material)
+
+	// only the configured properties are tracked as the server reports a
+	// default for every other property of the block, properties the server
+	// does not report are assumed to be unchanged
+	configured, diags := blockStateFromMap(ctx, data.State)
+	resp.Diagnostics.Append(diags...)
+
+	actual := block.blockState()
+	for k := range configured {
+		if v, ok := actual[k]; ok {
+			configured[k] = v
+		}
+	}
+
+	state, diags := blockStateToMap(ctx, configured, data.State)
+	resp.Diagnostics.Append(diags...)
+	data.State = state
+
+	if block.ID != "" {
+		data.Id = types.StringValue(worldID(data.World.ValueString(), block.ID))
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BlockResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := telemetry.Start(ctx, "minecraft_block.Update")
+	defer endOperation(span, &resp.Diagnostics)
+
+	var data BlockResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// only the material can change in place, placing a block over an
+	// existing one replaces it
+	br, diags := data.blockRequest(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	world := worldValue(data.World)
+
+	block, err := r.minecraftClient.queueCreateBlock(ctx, world, br)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "update block", err)
+		return
+	}
+
+	data.Id = types.StringValue(worldID(world, block.ID))
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BlockResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := telemetry.Start(ctx, "minecraft_block.Delete")
+	defer endOperation(span, &resp.Diagnostics)
+
+	var data BlockResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.minecraftClient.queueDeleteBlock(ctx, worldValue(data.World), blockRequest{X: intValue(data.X), Y: intValue(data.Y), Z: intValue(data.Z)})
+	if err != nil && !IsNotFound(err) {
+		addClientError(&resp.Diagnostics, "delete block", err)
+		return
+	}
+}
+
+// ModifyPlan plans the world of the block.
+func (r *BlockResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to do when the resource is being destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	planWorld(ctx, r.minecraftClient, req, resp)
+}
+
+// ImportState imports a block using its coordinates in the form "x,y,z", or
+// "world/x,y,z" for a block outside the overworld.
+func (r *BlockResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	world, coords := splitWorldID(req.ID)
+
+	x, y, z, err := parseCoordinates(coords)
+	if err == nil && world != "" {
+		err = validateWorld(world)
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: x,y,z or world/x,y,z. Got: %q, error: %s", req.ID, err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("world"), importWorld(world))...)
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("x"), numberValue(x))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("y"), numberValue(y))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("z"), numberValue(z))...)
+}
+
+func (m BlockResourceModel) blockRequest(ctx context.Context) (blockRequest, diag.Diagnostics) {
+	br := blockRequest{
+		X:        intValue(m.X),
+		Y:        intValue(m.Y),
+		Z:        intValue(m.Z),
+		Material: m.Material.ValueString(),
+	}
+
+	state, diags := blockStateFromMap(ctx, m.State)
+	br.setState(state)
+
+	return br, diags
+}
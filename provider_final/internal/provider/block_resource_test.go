@@ -0,0 +1,162 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccBlockResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccBlockResourceConfig("minecraft:stone"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("minecraft_block.stone", "x", "-1272"),
+					resource.TestCheckResourceAttr("minecraft_block.stone", "material", "minecraft:stone"),
+					resource.TestCheckResourceAttrSet("minecraft_block.stone", "id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:                         "minecraft_block.stone",
+				ImportState:                          true,
+				ImportStateId:                        "-1272,23,288",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "x",
+			},
+			// Drift testing, the block is mined in game and Terraform replaces it
+			{
+				PreConfig: func() {
+					if testAccServer != nil {
+						testAccServer.setBlock(-1272, 23, 288, "minecraft:air")
+					}
+				},
+				Config: testAccBlockResourceConfig("minecraft:stone"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("minecraft_block.stone", "material", "minecraft:stone"),
+					testAccCheckFakeBlock(-1272, 23, 288, "minecraft:stone"),
+				),
+			},
+			// Block state testing
+			{
+				Config: testAccBlockResourceStateConfig("east"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("minecraft_block.stairs", "state.facing", "east"),
+					resource.TestCheckResourceAttr("minecraft_block.stairs", "state.half", "top"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccBlockResourceConfig("minecraft:gold_block"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("minecraft_block.stone", "material", "minecraft:gold_block"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccBlockResourceInvalidMaterial(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccBlockResourceConfig("minecraft:stne"),
+				ExpectError: regexp.MustCompile(`did you mean "minecraft:stone"`),
+			},
+		},
+	})
+}
+
+func TestAccBlockResourceWorld(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The same coordinates in different worlds are different blocks
+			{
+				Config: testAccBlockResourceConfig("minecraft:stone") + testAccBlockResourceNetherConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("minecraft_block.stone", "world", defaultWorld),
+					resource.TestCheckResourceAttr("minecraft_block.nether", "world", "minecraft:the_nether"),
+					resource.TestMatchResourceAttr("minecraft_block.nether", "id", regexp.MustCompile("^minecraft:the_nether/")),
+					testAccCheckFakeBlock(-1272, 23, 288, "minecraft:stone"),
+					testAccCheckFakeBlockIn("minecraft:the_nether", -1272, 23, 288, "minecraft:netherrack"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:                         "minecraft_block.nether",
+				ImportState:                          true,
+				ImportStateId:                        "minecraft:the_nether/-1272,23,288",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "x",
+			},
+		},
+	})
+}
+
+// testAccCheckFakeBlock checks the material in the fake server world, it is a
+// no-op when running against a live server.
+func testAccCheckFakeBlock(x, y, z int, material string) resource.TestCheckFunc {
+	return testAccCheckFakeBlockIn(defaultWorld, x, y, z, material)
+}
+
+// testAccCheckFakeBlockIn checks the material in a world of the fake server.
+func testAccCheckFakeBlockIn(world string, x, y, z int, material string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if testAccServer == nil {
+			return nil
+		}
+
+		if got := testAccServer.materialIn(world, x, y, z); got != material {
+			return fmt.Errorf("expected block at %d,%d,%d in %s to be %s, got %s", x, y, z, world, material, got)
+		}
+
+		return nil
+	}
+}
+
+const testAccBlockResourceNetherConfig = `
+  resource "minecraft_block" "nether" {
+	  x = -1272
+	  y = 23
+	  z = 288
+	  material = "minecraft:netherrack"
+	  world = "minecraft:the_nether"
+	}
+`
+
+func testAccBlockResourceStateConfig(facing string) string {
+	return testAccBlockResourceConfig("minecraft:stone") + fmt.Sprintf(`
+  resource "minecraft_block" "stairs" {
+	  x = -1272
+	  y = 24
+	  z = 288
+	  material = "minecraft:oak_stairs"
+	  state = {
+	    facing = %q
+	    half = "top"
+	  }
+	}
+  `, facing)
+}
+
+func testAccBlockResourceConfig(material string) string {
+	return fmt.Sprintf(`
+  resource "minecraft_block" "stone" {
+	  x = -1272
+	  y = 23
+	  z = 288
+	  material = %q
+	}
+  `, material)
+}
@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// blockStateValues lists the block state properties understood by the
+// provider and the values they accept, a nil slice accepts any value that
+// is checked by the matching func in blockStateCheck.
+var blockStateValues = map[string][]string{
+	"facing":      {"north", "south", "east", "west", "up", "down"},
+	"half":        {"top", "bottom", "upper", "lower"},
+	"axis":        {"x", "y", "z"},
+	"waterlogged": {"true", "false"},
+	"shape":       {"straight", "inner_left", "inner_right", "outer_left", "outer_right"},
+	"hinge":       {"left", "right"},
+	"open":        {"true", "false"},
+	"powered":     {"true", "false"},
+	"lit":         {"true", "false"},
+	"type":        {"top", "bottom", "double"},
+	"rotation":    nil,
+}
+
+var blockStateCheck = map[string]func(string) bool{
+	// standing signs and banners have 16 rotations
+	"rotation": func(v string) bool {
+		i, err := strconv.Atoi(v)
+		return err == nil && i >= 0 && i <= 15
+	},
+}
+
+// validateBlockState returns an error describing the first invalid property
+// in state.
+func validateBlockState(state map[string]string) error {
+	keys := make([]string, 0, len(state))
+	for k := range state {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		v := state[k]
+
+		allowed, ok := blockStateValues[k]
+		if !ok {
+			return fmt.Errorf("unknown block state property %q, must be one of: %s", k, strings.Join(knownBlockStateKeys(), ", "))
+		}
+
+		if check, ok := blockStateCheck[k]; ok {
+			if !check(v) {
+				return fmt.Errorf("invalid value %q for block state property %q", v, k)
+			}
+
+			continue
+		}
+
+		valid := false
+		for _, a := range allowed {
+			if a == v {
+				valid = true
+				break
+			}
+		}
+
+		if !valid {
+			return fmt.Errorf("invalid value %q for block state property %q, must be one of: %s", v, k, strings.Join(allowed, ", "))
+		}
+	}
+
+	return nil
+}
+
+func knownBlockStateKeys() []string {
+	keys := make([]string, 0, len(blockStateValues))
+	for k := range blockStateValues {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// blockStateValidator validates a map attribute of block state properties.
+type blockStateValidator struct{}
+
+func (v blockStateValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("block state properties must be one of: %s", strings.Join(knownBlockStateKeys(), ", "))
+}
+
+func (v blockStateValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v blockStateValidator) ValidateMap(ctx context.Context, req validator.MapRequest, resp *validator.MapResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	state := map[string]string{}
+	resp.Diagnostics.Append(req.ConfigValue.ElementsAs(ctx, &state, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := validateBlockState(state); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Block State", err.Error())
+	}
+}
+
+// blockStateFromMap converts a map attribute to block state properties.
+func blockStateFromMap(ctx context.Context, m types.Map) (map[string]string, diag.Diagnostics) {
+	state := map[string]string{}
+	if m.IsNull() || m.IsUnknown() {
+		return state, nil
+	}
+
+	diags := m.ElementsAs(ctx, &state, false)
+	return state, diags
+}
+
+// blockStateToMap converts block state properties to a map attribute, an
+// empty state keeps an empty prior value or returns null so that an unset
+// attribute does not show a difference.
+func blockStateToMap(ctx context.Context, state map[string]string, prior types.Map) (types.Map, diag.Diagnostics) {
+	if len(state) == 0 {
+		if !prior.IsNull() && !prior.IsUnknown() && len(prior.Elements()) == 0 {
+			return prior, nil
+		}
+
+		return types.MapNull(types.StringType), nil
+	}
+
+	return types.MapValueFrom(ctx, types.StringType, state)
+}
@@ -0,0 +1,34 @@
+package provider
+
+import (
+	"testing"
+)
+
+func TestValidateBlockState(t *testing.T) {
+	cases := []struct {
+		name  string
+		state map[string]string
+		valid bool
+	}{
+		{"empty", map[string]string{}, true},
+		{"stairs", map[string]string{"facing": "north", "half": "top", "shape": "straight"}, true},
+		{"log", map[string]string{"axis": "y"}, true},
+		{"sign", map[string]string{"rotation": "12"}, true},
+		{"unknown key", map[string]string{"colour": "red"}, false},
+		{"invalid facing", map[string]string{"facing": "sideways"}, false},
+		{"invalid rotation", map[string]string{"rotation": "16"}, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateBlockState(tc.state)
+			if tc.valid && err != nil {
+				t.Fatalf("expected state to be valid, got: %s", err)
+			}
+
+			if !tc.valid && err == nil {
+				t.Fatal("expected state to be invalid")
+			}
+		})
+	}
+}
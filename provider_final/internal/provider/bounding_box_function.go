@@ -0,0 +1,90 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ function.Function = BoundingBoxFunction{}
+)
+
+var boundingBoxAttrTypes = map[string]attr.Type{
+	"start":  types.ObjectType{AttrTypes: positionAttrTypes},
+	"end":    types.ObjectType{AttrTypes: positionAttrTypes},
+	"width":  types.Int64Type,
+	"height": types.Int64Type,
+	"length": types.Int64Type,
+	"volume": types.Int64Type,
+}
+
+// boundingBoxModel is the result of the bounding_box function.
+type boundingBoxModel struct {
+	Start  positionModel `tfsdk:"start"`
+	End    positionModel `tfsdk:"end"`
+	Width  int64         `tfsdk:"width"`
+	Height int64         `tfsdk:"height"`
+	Length int64         `tfsdk:"length"`
+	Volume int64         `tfsdk:"volume"`
+}
+
+func NewBoundingBoxFunction() function.Function {
+	return BoundingBoxFunction{}
+}
+
+type BoundingBoxFunction struct{}
+
+func (r BoundingBoxFunction) Metadata(_ context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "bounding_box"
+}
+
+func (r BoundingBoxFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Bounding box of two corners",
+		MarkdownDescription: "Returns the minimum `start` and maximum `end` corners of the cuboid between two positions along with its `width` (X), `height` (Y), `length` (Z) and `volume` in blocks, both corners are inclusive",
+		Parameters: []function.Parameter{
+			function.ObjectParameter{
+				Name:                "a",
+				MarkdownDescription: "First corner with `x`, `y` and `z` attributes",
+				AttributeTypes:      positionAttrTypes,
+			},
+			function.ObjectParameter{
+				Name:                "b",
+				MarkdownDescription: "Opposite corner with `x`, `y` and `z` attributes",
+				AttributeTypes:      positionAttrTypes,
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: boundingBoxAttrTypes,
+		},
+	}
+}
+
+func (r BoundingBoxFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var a, b positionModel
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &a, &b))
+
+	if resp.Error != nil {
+		return
+	}
+
+	lo, hi := a.coordinate().bounds(b.coordinate())
+
+	result := boundingBoxModel{
+		Start:  newPositionModel(lo),
+		End:    newPositionModel(hi),
+		Width:  int64(hi.X - lo.X + 1),
+		Height: int64(hi.Y - lo.Y + 1),
+		Length: int64(hi.Z - lo.Z + 1),
+		Volume: lo.volume(hi),
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, result))
+}
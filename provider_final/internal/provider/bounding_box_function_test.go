@@ -0,0 +1,46 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestBoundingBoxFunction_Known(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				locals {
+					box = provider::minecraft::bounding_box({ x = 5, y = 70, z = -2 }, { x = 1, y = 64, z = 3 })
+				}
+
+				output "start" {
+					value = "${local.box.start.x},${local.box.start.y},${local.box.start.z}"
+				}
+
+				output "end" {
+					value = "${local.box.end.x},${local.box.end.y},${local.box.end.z}"
+				}
+
+				output "volume" {
+					value = local.box.volume
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("start", "1,64,-2"),
+					resource.TestCheckOutput("end", "5,70,3"),
+					resource.TestCheckOutput("volume", "210"),
+				),
+			},
+		},
+	})
+}
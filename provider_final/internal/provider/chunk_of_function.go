@@ -0,0 +1,80 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ function.Function = ChunkOfFunction{}
+)
+
+var chunkAttrTypes = map[string]attr.Type{
+	"x":     types.Int64Type,
+	"z":     types.Int64Type,
+	"start": types.ObjectType{AttrTypes: positionAttrTypes},
+	"end":   types.ObjectType{AttrTypes: positionAttrTypes},
+}
+
+// chunkModel is the result of the chunk_of function.
+type chunkModel struct {
+	X     int64         `tfsdk:"x"`
+	Z     int64         `tfsdk:"z"`
+	Start positionModel `tfsdk:"start"`
+	End   positionModel `tfsdk:"end"`
+}
+
+func NewChunkOfFunction() function.Function {
+	return ChunkOfFunction{}
+}
+
+type ChunkOfFunction struct{}
+
+func (r ChunkOfFunction) Metadata(_ context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "chunk_of"
+}
+
+func (r ChunkOfFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Chunk containing a position",
+		MarkdownDescription: "Returns the `x` and `z` coordinates of the 16x16 chunk containing a position, along with the `start` and `end` corners of the chunk at the height of the position",
+		Parameters: []function.Parameter{
+			function.ObjectParameter{
+				Name:                "pos",
+				MarkdownDescription: "Position with `x`, `y` and `z` attributes",
+				AttributeTypes:      positionAttrTypes,
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: chunkAttrTypes,
+		},
+	}
+}
+
+func (r ChunkOfFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var pos positionModel
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &pos))
+
+	if resp.Error != nil {
+		return
+	}
+
+	c := pos.coordinate()
+	cx, cz := c.chunk()
+
+	result := chunkModel{
+		X:     int64(cx),
+		Z:     int64(cz),
+		Start: newPositionModel(coordinate{cx * chunkSize, c.Y, cz * chunkSize}),
+		End:   newPositionModel(coordinate{cx*chunkSize + chunkSize - 1, c.Y, cz*chunkSize + chunkSize - 1}),
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, result))
+}
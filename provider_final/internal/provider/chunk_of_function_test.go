@@ -0,0 +1,56 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestChunkOfFunction_Known(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				locals {
+					chunk = provider::minecraft::chunk_of({ x = -1272, y = 23, z = 288 })
+				}
+
+				output "chunk" {
+					value = "${local.chunk.x},${local.chunk.z}"
+				}
+
+				output "start" {
+					value = "${local.chunk.start.x},${local.chunk.start.z}"
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("chunk", "-80,18"),
+					resource.TestCheckOutput("start", "-1280,288"),
+				),
+			},
+		},
+	})
+}
+
+func TestCoordinateChunk(t *testing.T) {
+	for c, want := range map[coordinate][2]int{
+		{0, 0, 0}:      {0, 0},
+		{15, 0, 16}:    {0, 1},
+		{-1, 0, -16}:   {-1, -1},
+		{-17, 0, -33}:  {-2, -3},
+		{-1272, 0, 31}: {-80, 1},
+	} {
+		x, z := c.chunk()
+		if x != want[0] || z != want[1] {
+			t.Fatalf("%s: expected chunk %v, got %d,%d", c, want, x, z)
+		}
+	}
+}
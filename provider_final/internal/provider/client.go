@@ -2,127 +2,251 @@ package provider
 
 import (
 	"bytes"
+	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
+	"io"
+	"math/rand"
 	"net/http"
-	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/telemetry"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
-const authHeader = "X-API-Key"
+// clientOptions control the timeout and retry behaviour of the client,
+// zero durations and a negative MaxRetries are replaced with the defaults
+// below.
+type clientOptions struct {
+	Timeout      time.Duration
+	MaxRetries   int
+	RetryWaitMin time.Duration
+	RetryWaitMax time.Duration
+
+	// TLSConfig is used for https endpoints, the system defaults are used
+	// when it is nil.
+	TLSConfig *tls.Config
+	// UserAgent is sent as the User-Agent header when it is not empty.
+	UserAgent string
+	// Auth adds the credentials to each request, the API key passed to
+	// newClient is sent in the X-API-Key header when it is nil.
+	Auth *authenticator
+	// Secrets are masked in the client logs along with the API key.
+	Secrets []string
+
+	// RequestsPerSecond limits the rate of requests with bursts of up to
+	// RequestBurst requests, zero does not limit the rate.
+	RequestsPerSecond float64
+	RequestBurst      int
+	// MaxConcurrentRequests limits the number of requests in flight, zero
+	// does not limit the number of requests.
+	MaxConcurrentRequests int
+}
+
+const (
+	defaultTimeout      = 30 * time.Second
+	defaultMaxRetries   = 4
+	defaultRetryWaitMin = 500 * time.Millisecond
+	defaultRetryWaitMax = 10 * time.Second
+)
 
 type client struct {
-	baseURL string
-	apiKey  string
+	baseURL    string
+	auth       *authenticator
+	secrets    []string
+	userAgent  string
+	httpClient *http.Client
+
+	// limiter is shared by every request of the client.
+	limiter *rateLimiter
+
+	// world is used by resources and data sources that do not set a world.
+	world string
+
+	// worlds records the worlds the server confirmed it supports, see
+	// checkWorld.
+	worlds sync.Map
+
+	maxRetries   int
+	retryWaitMin time.Duration
+	retryWaitMax time.Duration
+
+	// placements records the schema placements planned by this provider
+	// instance so that overlapping placements can be detected.
+	placements *schemaPlacements
+
+	// regionUnsupported is set once the server has responded that it does
+	// not support reading a region in a single request.
+	regionUnsupported atomic.Bool
+
+	// batcher coalesces the blocks queued by concurrent resources.
+	batcher *blockBatcher
+	// batchUnsupported is set once the server has responded that it does
+	// not support the batch block endpoints.
+	batchUnsupported atomic.Bool
 }
 
+// blockRequest places a block, facing and half use the same fields as the
+// schema format, other block state properties are sent in State.
 type blockRequest struct {
-	X        int    `json:"x"`
-	Y        int    `json:"y"`
-	Z        int    `json:"z"`
-	Material string `json:"material"`
+	X        int               `json:"x"`
+	Y        int               `json:"y"`
+	Z        int               `json:"z"`
+	Material string            `json:"material"`
+	Facing   string            `json:"facing,omitempty"`
+	Half     string            `json:"half,omitempty"`
+	State    map[string]string `json:"state,omitempty"`
 }
 
 type blockResponse struct {
-	ID       string `json:"id"`
-	X        int    `json:"x"`
-	Y        int    `json:"y"`
-	Z        int    `json:"z"`
-	Material string `json:"material"`
+	ID       string            `json:"id"`
+	X        int               `json:"x"`
+	Y        int               `json:"y"`
+	Z        int               `json:"z"`
+	Material string            `json:"material"`
+	Facing   string            `json:"facing,omitempty"`
+	Half     string            `json:"half,omitempty"`
+	State    map[string]string `json:"state,omitempty"`
 }
 
-type schemaRequest struct {
-	X        int    `json:"x"`
-	Y        int    `json:"y"`
-	Z        int    `json:"z"`
-	Rotation int    `json:"rotation"`
-	Schema   string `json:"schema"`
-}
+// setState sets the block state properties of the request.
+func (b *blockRequest) setState(state map[string]string) {
+	if len(state) == 0 {
+		b.Facing, b.Half, b.State = "", "", nil
+		return
+	}
 
-func newClient(url string, apiKey string) *client {
-	return &client{url, apiKey}
-}
+	b.State = map[string]string{}
+	for k, v := range state {
+		b.State[k] = v
+	}
 
-func (c *client) createBlock(block blockRequest) (*blockResponse, error) {
-	url := fmt.Sprintf("%s/v1/block", c.baseURL)
+	b.Facing = state["facing"]
+	b.Half = state["half"]
+}
 
-	// convert the object to json
-	d, err := json.Marshal(block)
-	if err != nil {
-		return nil, fmt.Errorf("unable to marshal block to json: %s", err)
+// blockState returns all block state properties of the response, including
+// facing and half.
+func (b *blockResponse) blockState() map[string]string {
+	state := map[string]string{}
+	for k, v := range b.State {
+		state[k] = v
 	}
 
-	r, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(d))
-	if err != nil {
-		return nil, fmt.Errorf("unable to create request: %s", err)
+	if b.Facing != "" {
+		state["facing"] = b.Facing
 	}
-	r.Header.Add(authHeader, c.apiKey)
 
-	resp, err := http.DefaultClient.Do(r)
-	if err != nil {
-		return nil, fmt.Errorf("unable to execute request: %s", err)
+	if b.Half != "" {
+		state["half"] = b.Half
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := ioutil.ReadAll(resp.Body)
-		return nil, fmt.Errorf("expected status 200, got status: %d, message: %s", resp.StatusCode, body)
+	return state
+}
+
+type schemaRequest struct {
+	X        int `json:"x"`
+	Y        int `json:"y"`
+	Z        int `json:"z"`
+	Rotation int `json:"rotation"`
+	// Schema is the content of the schema zip file.
+	Schema []byte `json:"-"`
+}
+
+func newClient(url string, apiKey string, opts clientOptions) *client {
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultTimeout
 	}
 
-	// process the block
-	blockResp := &blockResponse{}
-	err = json.NewDecoder(resp.Body).Decode(blockResp)
-	if err != nil {
-		return nil, fmt.Errorf("unable to decode block: %s", err)
+	if opts.MaxRetries < 0 {
+		opts.MaxRetries = defaultMaxRetries
 	}
 
-	return blockResp, nil
-}
+	if opts.RetryWaitMin <= 0 {
+		opts.RetryWaitMin = defaultRetryWaitMin
+	}
 
-func (c *client) deleteBlock(block blockRequest) error {
-	url := fmt.Sprintf("%s/v1/block/%d/%d/%d", c.baseURL, block.X, block.Y, block.Z)
-	r, err := http.NewRequest(http.MethodDelete, url, nil)
-	if err != nil {
-		return fmt.Errorf("unable to create request: %s", err)
+	if opts.RetryWaitMax <= 0 {
+		opts.RetryWaitMax = defaultRetryWaitMax
 	}
 
-	r.Header.Add(authHeader, c.apiKey)
+	if opts.Auth == nil {
+		opts.Auth = newStaticAuth(authTypeAPIKey, apiKey)
+	}
 
-	resp, err := http.DefaultClient.Do(r)
-	if err != nil {
-		return fmt.Errorf("unable to execute request: %s", err)
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if opts.TLSConfig != nil {
+		transport.TLSClientConfig = opts.TLSConfig
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := ioutil.ReadAll(resp.Body)
-		return fmt.Errorf("expected status 200, got status: %d, message: %s", resp.StatusCode, body)
+	c := &client{
+		baseURL:      url,
+		auth:         opts.Auth,
+		secrets:      append([]string{apiKey}, opts.Secrets...),
+		userAgent:    opts.UserAgent,
+		httpClient:   &http.Client{Timeout: opts.Timeout, Transport: transport},
+		limiter:      newRateLimiter(opts.RequestsPerSecond, opts.RequestBurst, opts.MaxConcurrentRequests),
+		world:        defaultWorld,
+		maxRetries:   opts.MaxRetries,
+		retryWaitMin: opts.RetryWaitMin,
+		retryWaitMax: opts.RetryWaitMax,
+		placements:   newSchemaPlacements(),
 	}
 
-	return nil
+	c.batcher = newBlockBatcher(c, defaultBatchWindow)
+
+	return c
 }
 
-func (c *client) getBlock(x, y, z int) (*blockResponse, error) {
-	url := fmt.Sprintf("%s/v1/block/%d/%d/%d", c.baseURL, x, y, z)
-	r, err := http.NewRequest(http.MethodGet, url, nil)
+func (c *client) createBlock(ctx context.Context, world string, block blockRequest) (*blockResponse, error) {
+	// convert the object to json
+	d, err := json.Marshal(block)
 	if err != nil {
-		return nil, fmt.Errorf("unable to create request: %s", err)
+		return nil, fmt.Errorf("unable to marshal block to json: %s", err)
 	}
 
-	r.Header.Add(authHeader, c.apiKey)
+	body, err := c.do(ctx, http.MethodPost, newRoute(world, "/v1/block"), bytes.NewReader(d), "")
+	if err != nil {
+		return nil, err
+	}
 
-	resp, err := http.DefaultClient.Do(r)
+	// process the block
+	blockResp := &blockResponse{}
+	err = json.Unmarshal(body, blockResp)
 	if err != nil {
-		return nil, fmt.Errorf("unable to execute request: %s", err)
+		return nil, fmt.Errorf("unable to decode block: %s", err)
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		// get the response body
-		body, _ := ioutil.ReadAll(resp.Body)
-		return nil, fmt.Errorf("expected status 200, got status: %d, message: %s", resp.StatusCode, body)
+	return blockResp, nil
+}
+
+func (c *client) deleteBlock(ctx context.Context, world string, block blockRequest) error {
+	route := newRoute(world, "/v1/block/{x}/{y}/{z}", block.X, block.Y, block.Z)
+
+	_, err := c.do(ctx, http.MethodDelete, route, nil, "")
+	return err
+}
+
+func (c *client) getBlock(ctx context.Context, world string, x, y, z int) (*blockResponse, error) {
+	route := newRoute(world, "/v1/block/{x}/{y}/{z}", x, y, z)
+
+	body, err := c.do(ctx, http.MethodGet, route, nil, "")
+	if err != nil {
+		return nil, err
 	}
 
 	// process the block
 	block := &blockResponse{}
-	err = json.NewDecoder(resp.Body).Decode(block)
+	err = json.Unmarshal(body, block)
 	if err != nil {
 		return nil, fmt.Errorf("unable to decode block: %s", err)
 	}
@@ -130,55 +254,123 @@ func (c *client) getBlock(x, y, z int) (*blockResponse, error) {
 	return block, nil
 }
 
-func (c *client) createSchema(schema schemaRequest) (string, error) {
-	url := fmt.Sprintf("%s/v1/schema/%d/%d/%d/%d", c.baseURL, schema.X, schema.Y, schema.Z, schema.Rotation)
-
-	// read the zip file
-	f, err := os.Open(schema.Schema)
-	if err != nil {
-		return "", fmt.Errorf("unable to open schema file: %s, err: %s", schema.Schema, err)
+// defaultRegionWorkers is the number of concurrent requests used to read a
+// region when the server does not support the bulk endpoint.
+const defaultRegionWorkers = 8
+
+// getRegion returns every block in the cuboid between start and end. The bulk
+// endpoint is used when the server supports it, otherwise the blocks are read
+// individually using up to workers concurrent requests.
+func (c *client) getRegion(ctx context.Context, world string, start, end coordinate, workers int) (map[coordinate]*blockResponse, error) {
+	lo, hi := start.bounds(end)
+
+	if !c.regionUnsupported.Load() {
+		route := newRoute(world, "/v1/blocks/{start_x}/{start_y}/{start_z}/{end_x}/{end_y}/{end_z}", lo.X, lo.Y, lo.Z, hi.X, hi.Y, hi.Z)
+
+		body, err := c.do(ctx, http.MethodGet, route, nil, "")
+		switch {
+		case err == nil:
+			list := []*blockResponse{}
+			if err := json.Unmarshal(body, &list); err != nil {
+				return nil, fmt.Errorf("unable to decode blocks: %s", err)
+			}
+
+			blocks := make(map[coordinate]*blockResponse, len(list))
+			for _, b := range list {
+				blocks[coordinate{b.X, b.Y, b.Z}] = b
+			}
+
+			return blocks, nil
+		case IsNotFound(err) || hasStatus(err, http.StatusMethodNotAllowed):
+			// the server does not support the bulk endpoint
+			c.regionUnsupported.Store(true)
+		default:
+			return nil, err
+		}
 	}
 
-	r, err := http.NewRequest(http.MethodPost, url, f)
-	if err != nil {
-		return "", fmt.Errorf("unable to create request: %s", err)
+	return c.getBlocks(ctx, world, lo.cuboid(hi), workers)
+}
+
+// getBlocks reads the blocks at coords using up to workers concurrent
+// requests, the first error cancels the remaining requests.
+func (c *client) getBlocks(ctx context.Context, world string, coords []coordinate, workers int) (map[coordinate]*blockResponse, error) {
+	if workers <= 0 {
+		workers = defaultRegionWorkers
 	}
-	r.Header.Add(authHeader, c.apiKey)
-	r.Header.Add("Content-Type", "application/zip")
 
-	resp, err := http.DefaultClient.Do(r)
-	if err != nil {
-		return "", fmt.Errorf("unable to execute request: %s", err)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var firstErr error
+
+	blocks := make(map[coordinate]*blockResponse, len(coords))
+	jobs := make(chan coordinate)
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for co := range jobs {
+				block, err := c.getBlock(ctx, world, co.X, co.Y, co.Z)
+
+				mu.Lock()
+				if err != nil && firstErr == nil {
+					firstErr = err
+					cancel()
+				}
+
+				if err == nil {
+					blocks[co] = block
+				}
+				mu.Unlock()
+			}
+		}()
 	}
 
-	body, _ := ioutil.ReadAll(resp.Body)
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("expected status 200, got status: %d, message: %s", resp.StatusCode, body)
+feed:
+	for _, co := range coords {
+		select {
+		case jobs <- co:
+		case <-ctx.Done():
+			break feed
+		}
 	}
 
-	return string(body), nil
-}
+	close(jobs)
+	wg.Wait()
 
-func (c *client) undoSchema(undoID string) error {
-	url := fmt.Sprintf("%s/v1/schema/undo/%s", c.baseURL, undoID)
-	r, err := http.NewRequest(http.MethodDelete, url, nil)
-	if err != nil {
-		return fmt.Errorf("unable to create request: %s", err)
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	if len(blocks) != len(coords) {
+		return nil, ctx.Err()
 	}
 
-	r.Header.Add(authHeader, c.apiKey)
+	return blocks, nil
+}
+
+func (c *client) createSchema(ctx context.Context, world string, schema schemaRequest) (string, error) {
+	route := newRoute(world, "/v1/schema/{x}/{y}/{z}/{rotation}", schema.X, schema.Y, schema.Z, schema.Rotation)
 
-	resp, err := http.DefaultClient.Do(r)
+	body, err := c.do(ctx, http.MethodPost, route, bytes.NewReader(schema.Schema), "application/zip")
 	if err != nil {
-		return fmt.Errorf("unable to execute request: %s", err)
+		return "", err
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := ioutil.ReadAll(resp.Body)
-		return fmt.Errorf("expected status 200, got status: %d, message: %s", resp.StatusCode, body)
-	}
+	return string(body), nil
+}
 
-	return nil
+func (c *client) undoSchema(ctx context.Context, world, undoID string) error {
+	route := newRoute(world, "/v1/schema/undo/{schema_id}", undoID)
+
+	_, err := c.do(ctx, http.MethodDelete, route, nil, "")
+	return err
 }
 
 type schemaDetailsResponse struct {
@@ -190,29 +382,327 @@ type schemaDetailsResponse struct {
 	EndZ   int `json:"endZ"`
 }
 
-func (c *client) getSchemaDetails(undoID string) (*schemaDetailsResponse, error) {
-	url := fmt.Sprintf("%s/v1/schema/details/%s", c.baseURL, undoID)
-	r, err := http.NewRequest(http.MethodGet, url, nil)
+func (c *client) getSchemaDetails(ctx context.Context, world, undoID string) (*schemaDetailsResponse, error) {
+	route := newRoute(world, "/v1/schema/details/{schema_id}", undoID)
+
+	body, err := c.do(ctx, http.MethodGet, route, nil, "")
 	if err != nil {
-		return nil, fmt.Errorf("unable to create request: %s", err)
+		return nil, err
 	}
 
-	r.Header.Add(authHeader, c.apiKey)
-
-	resp, err := http.DefaultClient.Do(r)
+	schemaResp := &schemaDetailsResponse{}
+	err = json.Unmarshal(body, schemaResp)
 	if err != nil {
-		return nil, fmt.Errorf("unable to execute request: %s", err)
+		return nil, fmt.Errorf("unable to decode response: %s", err)
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, nil
+	return schemaResp, nil
+}
+
+// schemaListItem is a placed schema returned by listSchemas.
+type schemaListItem struct {
+	ID string `json:"id"`
+	schemaDetailsResponse
+}
+
+// listSchemas returns every schema placement the server knows about in
+// world.
+func (c *client) listSchemas(ctx context.Context, world string) ([]schemaListItem, error) {
+	body, err := c.do(ctx, http.MethodGet, newRoute(world, "/v1/schema"), nil, "")
+	if err != nil {
+		return nil, err
 	}
 
-	schemaResp := &schemaDetailsResponse{}
-	err = json.NewDecoder(resp.Body).Decode(schemaResp)
+	items := []schemaListItem{}
+	err = json.Unmarshal(body, &items)
 	if err != nil {
 		return nil, fmt.Errorf("unable to decode response: %s", err)
 	}
 
-	return schemaResp, nil
+	return items, nil
+}
+
+// apiRoute is the path of an API request along with the template it was
+// expanded from, e.g. /v1/block/{x}/{y}/{z}. Spans are named after the
+// template and record the parameters as attributes.
+type apiRoute struct {
+	template string
+	path     string
+	world    string
+	attrs    []attribute.KeyValue
+}
+
+// newRoute expands the {name} parameters of template with values, in order,
+// and adds the world.
+func newRoute(world, template string, values ...any) apiRoute {
+	r := apiRoute{template: template, world: world}
+	path := template
+
+	for _, v := range values {
+		start, end := strings.Index(path, "{"), strings.Index(path, "}")
+		key := "minecraft." + path[start+1:end]
+
+		if i, ok := v.(int); ok {
+			r.attrs = append(r.attrs, attribute.Int(key, i))
+		} else {
+			r.attrs = append(r.attrs, attribute.String(key, fmt.Sprint(v)))
+		}
+
+		path = path[:start] + fmt.Sprint(v) + path[end+1:]
+	}
+
+	if world != "" {
+		r.attrs = append(r.attrs, attribute.String("minecraft.world", world))
+	}
+
+	r.path = worldRoute(world, path)
+
+	return r
+}
+
+// do executes a request against the API route and returns the full response
+// body, the body is always closed before returning. Any status other than 200
+// is returned as an *APIError.
+// Idempotent requests (GET and DELETE) are retried with exponential backoff
+// when the connection fails or the server returns a 5xx status, other
+// requests are attempted once as their body can only be read once.
+// A request rejected with a 401 status is sent once more, without waiting,
+// when reloading the credentials returns a different token. The server
+// rejects the request before reading it so the body can be sent again.
+// Each call is traced as a single client span, the trace context is sent to
+// the server.
+// Requests wait for the rate limiter before they are sent. A request rejected
+// with a 429 status was not processed by the server, so it is retried
+// regardless of its method. When a 429 or 503 response has a Retry-After
+// header every request of the client waits for the requested time instead of
+// the backoff.
+// Requests outside the default world fail unless the server supports worlds,
+// see checkWorld.
+func (c *client) do(ctx context.Context, method string, route apiRoute, body io.Reader, contentType string) (_ []byte, rerr error) {
+	ctx, span := telemetry.Start(ctx, method+" "+route.template,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(route.attrs...),
+		trace.WithAttributes(
+			semconv.HTTPRequestMethodKey.String(method),
+			semconv.HTTPRoute(route.template),
+		),
+	)
+	defer telemetry.End(span, func() error { return rerr })
+
+	if method != http.MethodGet {
+		if err := c.checkWorld(ctx, route.world); err != nil {
+			return nil, err
+		}
+	}
+
+	attempts := 1
+	if isIdempotent(method) {
+		attempts += c.maxRetries
+	}
+
+	var lastErr error
+	refreshed, resend := false, false
+	throttled := 0
+
+	logCtx := c.logContext(ctx)
+	maskedToken := ""
+
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 && !resend {
+			if err := c.wait(ctx, attempt); err != nil {
+				return nil, err
+			}
+		}
+
+		resend = false
+
+		token, err := c.auth.credential(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load credentials: %s", err)
+		}
+
+		r, err := http.NewRequestWithContext(ctx, method, c.baseURL+route.path, body)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create request: %s", err)
+		}
+
+		c.auth.apply(r, token)
+		otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(r.Header))
+
+		// the token changes when the credentials are reloaded
+		if token != maskedToken {
+			logCtx = maskLogStrings(logCtx, token)
+			maskedToken = token
+		}
+
+		fields := map[string]interface{}{
+			"method":  method,
+			"url":     r.URL.String(),
+			"attempt": attempt + 1,
+		}
+
+		if c.userAgent != "" {
+			r.Header.Set("User-Agent", c.userAgent)
+		}
+
+		if contentType != "" {
+			r.Header.Add("Content-Type", contentType)
+		}
+
+		if attempt > 0 {
+			span.SetAttributes(semconv.HTTPRequestResendCount(attempt))
+		}
+
+		release, err := c.limiter.acquire(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		tflog.SubsystemDebug(logCtx, clientLogSubsystem, "sending request", fields)
+		if body != nil {
+			tflog.SubsystemTrace(logCtx, clientLogSubsystem, "request body", map[string]interface{}{
+				"body": lazyBody{body: body, contentType: contentType, secrets: c.secrets, token: token},
+			})
+		}
+
+		start := time.Now()
+
+		resp, err := c.httpClient.Do(r)
+		fields["latency"] = time.Since(start).String()
+
+		if err != nil {
+			release()
+
+			// do not retry when Terraform has cancelled the operation
+			if ctx.Err() != nil {
+				return nil, fmt.Errorf("unable to execute request: %w", ctx.Err())
+			}
+
+			lastErr = fmt.Errorf("unable to execute request: %s", err)
+
+			fields["error"] = lastErr.Error()
+			tflog.SubsystemWarn(logCtx, clientLogSubsystem, "request failed", fields)
+
+			continue
+		}
+
+		span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+
+		respBody, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		release()
+
+		fields["status"] = resp.StatusCode
+		tflog.SubsystemDebug(logCtx, clientLogSubsystem, "received response", fields)
+		tflog.SubsystemTrace(logCtx, clientLogSubsystem, "response body", map[string]interface{}{
+			"body": logBody(respBody),
+		})
+
+		if err != nil {
+			lastErr = fmt.Errorf("unable to read response body: %s", err)
+			continue
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			lastErr = &APIError{
+				StatusCode: resp.StatusCode,
+				Method:     method,
+				Endpoint:   route.path,
+				Message:    string(respBody),
+			}
+
+			if d, ok := retryAfter(resp.Header, time.Now()); ok && (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable) {
+				fields["retry_after"] = d.String()
+				c.limiter.pause(d)
+				resend = true
+			}
+
+			if resp.StatusCode == http.StatusTooManyRequests && throttled < c.maxRetries && rewind(body) {
+				tflog.SubsystemWarn(logCtx, clientLogSubsystem, "rate limited by the server", fields)
+
+				throttled++
+				if !isIdempotent(method) {
+					attempts++
+				}
+
+				continue
+			}
+
+			if resp.StatusCode >= http.StatusInternalServerError {
+				continue
+			}
+
+			if resp.StatusCode == http.StatusUnauthorized && !refreshed {
+				refreshed = true
+
+				changed, err := c.auth.refresh(ctx, token)
+				if err != nil {
+					return nil, fmt.Errorf("%w, unable to reload credentials: %s", lastErr, err)
+				}
+
+				if changed && rewind(body) {
+					resend = true
+					attempts++
+					continue
+				}
+			}
+
+			return nil, lastErr
+		}
+
+		if err := c.confirmWorld(route.world, resp.Header); err != nil {
+			return nil, err
+		}
+
+		return respBody, nil
+	}
+
+	return nil, lastErr
+}
+
+// wait blocks for the backoff period of the given attempt, returning early
+// with an error if the context is cancelled.
+func (c *client) wait(ctx context.Context, attempt int) error {
+	t := time.NewTimer(backoff(c.retryWaitMin, c.retryWaitMax, attempt))
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("request cancelled while waiting to retry: %w", ctx.Err())
+	case <-t.C:
+		return nil
+	}
+}
+
+// backoff returns an exponentially increasing duration capped at max, with
+// jitter so that parallel resources do not retry in lock step.
+func backoff(min, max time.Duration, attempt int) time.Duration {
+	wait := min << uint(attempt-1)
+	if wait > max || wait <= 0 {
+		wait = max
+	}
+
+	// full jitter in the upper half of the window
+	half := wait / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// rewind seeks body back to the start so that it can be sent again, it
+// returns false when the body can not be rewound.
+func rewind(body io.Reader) bool {
+	if body == nil {
+		return true
+	}
+
+	s, ok := body.(io.Seeker)
+	if !ok {
+		return false
+	}
+
+	_, err := s.Seek(0, io.SeekStart)
+	return err == nil
+}
+
+func isIdempotent(method string) bool {
+	return method == http.MethodGet || method == http.MethodDelete
 }
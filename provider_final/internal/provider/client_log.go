@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// clientLogSubsystem is the tflog subsystem used for API requests, its level
+// is set with TF_LOG_PROVIDER_MINECRAFT_CLIENT and defaults to the provider
+// level.
+const clientLogSubsystem = "minecraft_client"
+
+// maxLogBody is the number of bytes of a request or response body that are
+// logged.
+const maxLogBody = 1024
+
+// logContext returns ctx with the client log subsystem, it is created once
+// for each request rather than for every attempt. The secrets in the provider
+// configuration are masked in every message and field.
+func (c *client) logContext(ctx context.Context) context.Context {
+	ctx = tflog.NewSubsystem(ctx, clientLogSubsystem, tflog.WithLevelFromEnv("TF_LOG_PROVIDER", "MINECRAFT", "CLIENT"))
+
+	return maskLogStrings(ctx, c.secrets...)
+}
+
+// maskLogStrings masks the secrets in every message and field of the client
+// log subsystem in ctx, empty secrets are ignored.
+func maskLogStrings(ctx context.Context, secrets ...string) context.Context {
+	masked := make([]string, 0, len(secrets))
+	for _, s := range secrets {
+		if s != "" {
+			masked = append(masked, s)
+		}
+	}
+
+	if len(masked) == 0 {
+		return ctx
+	}
+
+	ctx = tflog.SubsystemMaskAllFieldValuesStrings(ctx, clientLogSubsystem, masked...)
+	return tflog.SubsystemMaskMessageStrings(ctx, clientLogSubsystem, masked...)
+}
+
+// lazyBody formats a request body for logging only when the log entry is
+// written, so that the body is not read when trace logging is disabled. tflog
+// only masks string fields, so the secrets are masked here.
+type lazyBody struct {
+	body        io.Reader
+	contentType string
+	secrets     []string
+	token       string
+}
+
+func (b lazyBody) String() string {
+	s := logRequestBody(b.body, b.contentType)
+	for _, secret := range append([]string{b.token}, b.secrets...) {
+		if secret != "" {
+			s = strings.ReplaceAll(s, secret, "***")
+		}
+	}
+
+	return s
+}
+
+func (b lazyBody) MarshalJSON() ([]byte, error) {
+	return json.Marshal(b.String())
+}
+
+// logRequestBody returns the request body for logging, binary bodies such as
+// schema zips are logged as their size. The body is rewound so that it can
+// still be sent.
+func logRequestBody(body io.Reader, contentType string) string {
+	if body == nil {
+		return ""
+	}
+
+	if r, ok := body.(*bytes.Reader); ok && contentType != "" {
+		return fmt.Sprintf("(%d bytes of %s)", r.Len(), contentType)
+	}
+
+	s, ok := body.(io.ReadSeeker)
+	if !ok {
+		return "(not logged)"
+	}
+
+	data, err := io.ReadAll(s)
+	if _, seekErr := s.Seek(0, io.SeekStart); err != nil || seekErr != nil {
+		return "(not logged)"
+	}
+
+	return logBody(data)
+}
+
+// logBody returns body truncated to maxLogBody bytes.
+func logBody(body []byte) string {
+	if len(body) <= maxLogBody {
+		return string(body)
+	}
+
+	return fmt.Sprintf("%s... (%d bytes truncated)", body[:maxLogBody], len(body)-maxLogBody)
+}
@@ -0,0 +1,139 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestClientLogging(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// echo the key so that masking can be checked
+		w.Write([]byte(`{"id":"1","x":1,"y":2,"z":3,"material":"minecraft:stone","key":"` + r.Header.Get(authHeader) + `"}`))
+	}))
+	defer srv.Close()
+
+	opts := testClientOptions()
+	opts.Secrets = []string{"client-key-pem"}
+
+	c := newClient(srv.URL, "supersecretkey", opts)
+
+	var out bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &out)
+
+	if _, err := c.createBlock(ctx, defaultWorld, blockRequest{X: 1, Y: 2, Z: 3, Material: "minecraft:stone"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(out.String(), "supersecretkey") {
+		t.Fatalf("expected the API key to be masked, got: %s", out.String())
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&out)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	messages := map[string]map[string]interface{}{}
+	for _, e := range entries {
+		if e["@module"] != "provider."+clientLogSubsystem {
+			t.Fatalf("expected the %s subsystem, got: %v", clientLogSubsystem, e["@module"])
+		}
+
+		messages[e["@message"].(string)] = e
+	}
+
+	resp, ok := messages["received response"]
+	if !ok {
+		t.Fatalf("expected a response to be logged, got: %v", entries)
+	}
+
+	if resp["method"] != http.MethodPost || resp["url"] != srv.URL+"/v1/block" || resp["status"] != float64(http.StatusOK) || resp["latency"] == "" {
+		t.Fatalf("unexpected response fields: %v", resp)
+	}
+
+	if body := messages["request body"]["body"]; !strings.Contains(body.(string), `"material":"minecraft:stone"`) {
+		t.Fatalf("expected the request body to be logged, got: %v", body)
+	}
+
+	if body := messages["response body"]["body"]; !strings.Contains(body.(string), `"key":"***"`) {
+		t.Fatalf("expected the key in the response body to be masked, got: %v", body)
+	}
+}
+
+func TestClientLoggingLevel(t *testing.T) {
+	t.Setenv("TF_LOG_PROVIDER_MINECRAFT_CLIENT", "ERROR")
+
+	srv := newFakeMinecraftServer(t)
+	c := newClient(srv.URL, fakeAPIKey, testClientOptions())
+
+	var out bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &out)
+
+	if _, err := c.getBlock(ctx, defaultWorld, 0, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	if out.Len() != 0 {
+		t.Fatalf("expected no logs below the subsystem level, got: %s", out.String())
+	}
+}
+
+// readCounter counts the reads of a request body.
+type readCounter struct {
+	io.ReadSeeker
+	reads int
+}
+
+func (r *readCounter) Read(p []byte) (int, error) {
+	r.reads++
+	return r.ReadSeeker.Read(p)
+}
+
+func TestLazyBody(t *testing.T) {
+	t.Setenv("TF_LOG_PROVIDER_MINECRAFT_CLIENT", "DEBUG")
+
+	c := newClient("http://localhost", fakeAPIKey, testClientOptions())
+	ctx := c.logContext(tflogtest.RootLogger(context.Background(), io.Discard))
+
+	body := &readCounter{ReadSeeker: strings.NewReader(`{"api_key":"` + fakeAPIKey + `"}`)}
+	tflog.SubsystemTrace(ctx, clientLogSubsystem, "request body", map[string]interface{}{
+		"body": lazyBody{body: body, contentType: "application/json", secrets: c.secrets},
+	})
+
+	if body.reads != 0 {
+		t.Fatal("expected the body to be unread when trace logging is disabled")
+	}
+
+	got := lazyBody{body: body, contentType: "application/json", secrets: c.secrets, token: "token"}.String()
+	if strings.Contains(got, fakeAPIKey) || !strings.Contains(got, "***") {
+		t.Fatalf("expected the secrets to be masked, got: %s", got)
+	}
+}
+
+func TestLogBody(t *testing.T) {
+	if got := logBody([]byte("short")); got != "short" {
+		t.Fatalf("expected short bodies to be logged in full, got: %s", got)
+	}
+
+	long := bytes.Repeat([]byte("a"), maxLogBody+10)
+	if got := logBody(long); !strings.HasSuffix(got, "... (10 bytes truncated)") || len(got) > maxLogBody+30 {
+		t.Fatalf("expected long bodies to be truncated, got: %s", got)
+	}
+
+	zip := bytes.NewReader([]byte("PK..."))
+	if got := logRequestBody(zip, "application/zip"); got != "(5 bytes of application/zip)" {
+		t.Fatalf("expected binary bodies to be logged as their size, got: %s", got)
+	}
+
+	if zip.Len() != 5 {
+		t.Fatal("expected the body to be unread")
+	}
+}
@@ -0,0 +1,445 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func testClientOptions() clientOptions {
+	return clientOptions{
+		MaxRetries:   3,
+		RetryWaitMin: time.Millisecond,
+		RetryWaitMax: 5 * time.Millisecond,
+	}
+}
+
+func TestClientRetriesIdempotentRequests(t *testing.T) {
+	var calls int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		w.Write([]byte(`{"id":"1","x":1,"y":2,"z":3,"material":"minecraft:stone"}`))
+	}))
+	defer srv.Close()
+
+	c := newClient(srv.URL, "key", testClientOptions())
+
+	block, err := c.getBlock(context.Background(), defaultWorld, 1, 2, 3)
+	if err != nil {
+		t.Fatalf("expected no error, got: %s", err)
+	}
+
+	if block.Material != "minecraft:stone" {
+		t.Fatalf("expected minecraft:stone, got: %s", block.Material)
+	}
+
+	if calls != 3 {
+		t.Fatalf("expected 3 calls, got: %d", calls)
+	}
+}
+
+func TestClientDoesNotRetryPost(t *testing.T) {
+	var calls int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	c := newClient(srv.URL, "key", testClientOptions())
+
+	_, err := c.createBlock(context.Background(), defaultWorld, blockRequest{Material: "minecraft:stone"})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if calls != 1 {
+		t.Fatalf("expected 1 call, got: %d", calls)
+	}
+}
+
+func TestClientHonoursCancellation(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	opts := testClientOptions()
+	opts.RetryWaitMin = time.Hour
+	opts.RetryWaitMax = time.Hour
+
+	c := newClient(srv.URL, "key", opts)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := c.undoSchema(ctx, defaultWorld, "abc")
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if ctx.Err() == nil {
+		t.Fatal("expected the request to wait for the context to be cancelled")
+	}
+}
+
+func TestClientReturnsAPIError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte("schema not found"))
+	}))
+	defer srv.Close()
+
+	c := newClient(srv.URL, "key", testClientOptions())
+
+	_, err := c.getSchemaDetails(context.Background(), defaultWorld, "abc")
+	if !IsNotFound(err) {
+		t.Fatalf("expected a not found error, got: %v", err)
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected an *APIError, got: %T", err)
+	}
+
+	if apiErr.Method != http.MethodGet || apiErr.Endpoint != "/v1/schema/details/abc" || apiErr.Message != "schema not found" {
+		t.Fatalf("unexpected error details: %+v", apiErr)
+	}
+
+	if IsUnauthorized(err) || IsConflict(err) {
+		t.Fatal("expected only IsNotFound to match")
+	}
+}
+
+func TestAddClientErrorUnauthorized(t *testing.T) {
+	var diags diag.Diagnostics
+	addClientError(&diags, "read block", &APIError{StatusCode: http.StatusForbidden, Method: http.MethodGet, Endpoint: "/v1/block"})
+
+	if !diags.HasError() {
+		t.Fatal("expected an error")
+	}
+
+	d, ok := diags.Errors()[0].(diag.DiagnosticWithPath)
+	if !ok || !d.Path().Equal(path.Root("api_key")) {
+		t.Fatalf("expected the error to be reported against api_key, got: %v", diags.Errors()[0])
+	}
+
+	if !strings.Contains(d.Detail(), envAPIKey) {
+		t.Fatalf("expected the detail to mention %s, got: %s", envAPIKey, d.Detail())
+	}
+}
+
+func TestClientAgainstFakeServer(t *testing.T) {
+	srv := newFakeMinecraftServer(t)
+	c := newClient(srv.URL, fakeAPIKey, testClientOptions())
+	ctx := context.Background()
+
+	_, err := c.createBlock(ctx, defaultWorld, blockRequest{X: 1, Y: 2, Z: 3, Material: "minecraft:stone"})
+	if err != nil {
+		t.Fatalf("expected no error creating block, got: %s", err)
+	}
+
+	if m := srv.material(1, 2, 3); m != "minecraft:stone" {
+		t.Fatalf("expected minecraft:stone, got: %s", m)
+	}
+
+	stairs := blockRequest{X: 1, Y: 3, Z: 3, Material: "minecraft:oak_stairs"}
+	stairs.setState(map[string]string{"facing": "east", "half": "top", "waterlogged": "true"})
+
+	if _, err := c.createBlock(ctx, defaultWorld, stairs); err != nil {
+		t.Fatalf("expected no error creating stairs, got: %s", err)
+	}
+
+	block, err := c.getBlock(ctx, defaultWorld, 1, 3, 3)
+	if err != nil {
+		t.Fatalf("expected no error reading stairs, got: %s", err)
+	}
+
+	if s := block.blockState(); s["facing"] != "east" || s["half"] != "top" || s["waterlogged"] != "true" {
+		t.Fatalf("unexpected block state: %v", s)
+	}
+
+	car, err := os.ReadFile("../../schemas/car.zip")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id, err := c.createSchema(ctx, defaultWorld, schemaRequest{X: 0, Y: 0, Z: 0, Rotation: 0, Schema: car})
+	if err != nil {
+		t.Fatalf("expected no error creating schema, got: %s", err)
+	}
+
+	details, err := c.getSchemaDetails(ctx, defaultWorld, id)
+	if err != nil {
+		t.Fatalf("expected no error reading schema, got: %s", err)
+	}
+
+	if details.StartX != 0 || details.EndX <= details.StartX {
+		t.Fatalf("unexpected schema bounds: %+v", details)
+	}
+
+	if err := c.undoSchema(ctx, defaultWorld, id); err != nil {
+		t.Fatalf("expected no error undoing schema, got: %s", err)
+	}
+
+	if _, err := c.getSchemaDetails(ctx, defaultWorld, id); !IsNotFound(err) {
+		t.Fatalf("expected schema to be removed, got: %v", err)
+	}
+}
+
+func TestClientFakeServerRejectsInvalidKey(t *testing.T) {
+	srv := newFakeMinecraftServer(t)
+	c := newClient(srv.URL, "wrong", testClientOptions())
+
+	_, err := c.getBlock(context.Background(), defaultWorld, 0, 0, 0)
+	if !IsUnauthorized(err) {
+		t.Fatalf("expected an unauthorized error, got: %v", err)
+	}
+}
+
+func TestClientFakeServerInjectedFailures(t *testing.T) {
+	srv := newFakeMinecraftServer(t)
+	srv.failNext(http.MethodGet, "/v1/block", http.StatusServiceUnavailable, 2)
+
+	c := newClient(srv.URL, fakeAPIKey, testClientOptions())
+
+	block, err := c.getBlock(context.Background(), defaultWorld, 0, 0, 0)
+	if err != nil {
+		t.Fatalf("expected the retried request to succeed, got: %s", err)
+	}
+
+	if block.Material != fakeAirMaterial {
+		t.Fatalf("expected %s, got: %s", fakeAirMaterial, block.Material)
+	}
+}
+
+func TestClientGetRegion(t *testing.T) {
+	srv := newFakeMinecraftServer(t)
+	srv.setBlock(1, 1, 1, "minecraft:stone")
+
+	c := newClient(srv.URL, fakeAPIKey, testClientOptions())
+	ctx := context.Background()
+
+	start, end := coordinate{2, 2, 2}, coordinate{0, 0, 0}
+
+	blocks, err := c.getRegion(ctx, defaultWorld, start, end, 4)
+	if err != nil {
+		t.Fatalf("expected no error reading region, got: %s", err)
+	}
+
+	if len(blocks) != 27 || blocks[coordinate{1, 1, 1}].Material != "minecraft:stone" {
+		t.Fatalf("unexpected region: %d blocks", len(blocks))
+	}
+
+	// servers without the bulk endpoint are read block by block
+	srv.failNext(http.MethodGet, "/v1/blocks/", http.StatusNotFound, 1)
+	c = newClient(srv.URL, fakeAPIKey, testClientOptions())
+
+	blocks, err = c.getRegion(ctx, defaultWorld, start, end, 4)
+	if err != nil {
+		t.Fatalf("expected no error reading region, got: %s", err)
+	}
+
+	if !c.regionUnsupported.Load() {
+		t.Fatal("expected the bulk endpoint to be marked as unsupported")
+	}
+
+	if len(blocks) != 27 || blocks[coordinate{1, 1, 1}].Material != "minecraft:stone" {
+		t.Fatalf("unexpected region: %d blocks", len(blocks))
+	}
+
+	srv.failNext(http.MethodGet, "/v1/block/", http.StatusBadRequest, 1)
+
+	if _, err := c.getRegion(ctx, defaultWorld, start, end, 4); !hasStatus(err, http.StatusBadRequest) {
+		t.Fatalf("expected the failed request to be returned, got: %v", err)
+	}
+}
+
+func TestClientGetRegionUnsupported(t *testing.T) {
+	srv := newFakeMinecraftServer(t)
+	srv.setBlock(1, 1, 1, "minecraft:stone")
+	srv.disableExtensions()
+
+	c := newClient(srv.URL, fakeAPIKey, testClientOptions())
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		blocks, err := c.getRegion(ctx, defaultWorld, coordinate{0, 0, 0}, coordinate{2, 2, 2}, 4)
+		if err != nil {
+			t.Fatalf("expected no error reading region, got: %s", err)
+		}
+
+		if len(blocks) != 27 || blocks[coordinate{1, 1, 1}].Material != "minecraft:stone" {
+			t.Fatalf("unexpected region: %d blocks", len(blocks))
+		}
+	}
+
+	// the bulk endpoint is only tried once
+	if n := srv.requestCount(http.MethodGet, "/v1/blocks/0/0/0/2/2/2"); n != 1 {
+		t.Fatalf("expected a single bulk request, got: %d", n)
+	}
+}
+
+func TestClientListSchemas(t *testing.T) {
+	srv := newFakeMinecraftServer(t)
+	c := newClient(srv.URL, fakeAPIKey, testClientOptions())
+	ctx := context.Background()
+
+	car, err := os.ReadFile("../../schemas/car.zip")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.createSchema(ctx, defaultWorld, schemaRequest{Schema: car}); err != nil {
+		t.Fatal(err)
+	}
+
+	items, err := c.listSchemas(ctx, defaultWorld)
+	if err != nil {
+		t.Fatalf("expected no error listing schemas, got: %s", err)
+	}
+
+	if len(items) != 1 {
+		t.Fatalf("expected the placed schema to be listed, got: %v", items)
+	}
+
+	// servers without the endpoint respond with 404, the data source reports
+	// it as unsupported
+	srv.disableExtensions()
+
+	if _, err := c.listSchemas(ctx, defaultWorld); !IsNotFound(err) {
+		t.Fatalf("expected a not found error, got: %v", err)
+	}
+}
+
+func TestClientWorld(t *testing.T) {
+	f := newFakeMinecraftServer(t)
+	c := newClient(f.URL, fakeAPIKey, testClientOptions())
+	ctx := context.Background()
+
+	nether := "minecraft:the_nether"
+
+	if _, err := c.createBlock(ctx, nether, blockRequest{X: 1, Y: 2, Z: 3, Material: "minecraft:netherrack"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := f.materialIn(nether, 1, 2, 3); got != "minecraft:netherrack" {
+		t.Fatalf("expected minecraft:netherrack in the nether, got %s", got)
+	}
+
+	block, err := c.getBlock(ctx, defaultWorld, 1, 2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if block.Material != fakeAirMaterial {
+		t.Fatalf("expected the overworld to be unchanged, got %s", block.Material)
+	}
+}
+
+func TestClientWorldUnsupported(t *testing.T) {
+	posts := 0
+
+	// servers that predate worlds ignore the world parameter
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			posts++
+		}
+
+		w.Write([]byte(`{"id":"1","x":0,"y":0,"z":0,"material":"minecraft:air"}`))
+	}))
+	defer srv.Close()
+
+	c := newClient(srv.URL, fakeAPIKey, testClientOptions())
+	ctx := context.Background()
+
+	if _, err := c.getBlock(ctx, defaultWorld, 0, 0, 0); err != nil {
+		t.Fatalf("expected the default world to be supported, got: %s", err)
+	}
+
+	if _, err := c.getBlock(ctx, "minecraft:the_nether", 0, 0, 0); err == nil || !strings.Contains(err.Error(), worldHeader) {
+		t.Fatalf("expected reading another world to fail, got: %v", err)
+	}
+
+	if _, err := c.createBlock(ctx, "minecraft:the_nether", blockRequest{Material: "minecraft:netherrack"}); err == nil {
+		t.Fatal("expected placing a block in another world to fail")
+	}
+
+	if posts != 0 {
+		t.Fatalf("expected the block not to be sent to the server, got %d requests", posts)
+	}
+}
+
+func TestClientTracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	t.Cleanup(func() {
+		otel.SetTracerProvider(noop.NewTracerProvider())
+		otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+	})
+
+	var traceparent string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.Header().Set(worldHeader, r.URL.Query().Get(worldParameter))
+		w.Write([]byte(`{"id":"1","x":1,"y":2,"z":3,"material":"minecraft:stone"}`))
+	}))
+	defer srv.Close()
+
+	c := newClient(srv.URL, "key", testClientOptions())
+
+	if _, err := c.getBlock(context.Background(), "minecraft:the_nether", 1, 2, 3); err != nil {
+		t.Fatal(err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got: %d", len(spans))
+	}
+
+	span := spans[0]
+	if span.Name() != "GET /v1/block/{x}/{y}/{z}" {
+		t.Fatalf("unexpected span name: %s", span.Name())
+	}
+
+	attrs := map[attribute.Key]attribute.Value{}
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+
+	if attrs["minecraft.x"].AsInt64() != 1 || attrs["minecraft.z"].AsInt64() != 3 || attrs["minecraft.world"].AsString() != "minecraft:the_nether" {
+		t.Fatalf("expected the coordinates and world to be recorded, got: %v", attrs)
+	}
+
+	if attrs["http.response.status_code"].AsInt64() != http.StatusOK {
+		t.Fatalf("expected the status to be recorded, got: %v", attrs)
+	}
+
+	if !strings.Contains(traceparent, span.SpanContext().TraceID().String()) {
+		t.Fatalf("expected the trace context to be sent, got: %q", traceparent)
+	}
+}
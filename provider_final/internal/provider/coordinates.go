@@ -0,0 +1,213 @@
+package provider
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// coordinate is a single block position in the world.
+type coordinate struct {
+	X, Y, Z int
+}
+
+func (c coordinate) String() string {
+	return fmt.Sprintf("%d,%d,%d", c.X, c.Y, c.Z)
+}
+
+// positionAttrTypes are the attributes of the position objects accepted and
+// returned by the provider functions.
+var positionAttrTypes = map[string]attr.Type{
+	"x": types.Int64Type,
+	"y": types.Int64Type,
+	"z": types.Int64Type,
+}
+
+// positionModel is a position argument or result of a provider function.
+type positionModel struct {
+	X int64 `tfsdk:"x"`
+	Y int64 `tfsdk:"y"`
+	Z int64 `tfsdk:"z"`
+}
+
+func newPositionModel(c coordinate) positionModel {
+	return positionModel{int64(c.X), int64(c.Y), int64(c.Z)}
+}
+
+func (p positionModel) coordinate() coordinate {
+	return coordinate{int(p.X), int(p.Y), int(p.Z)}
+}
+
+// bounds returns the minimum and maximum corners of the cuboid between c and
+// other.
+func (c coordinate) bounds(other coordinate) (coordinate, coordinate) {
+	return coordinate{min(c.X, other.X), min(c.Y, other.Y), min(c.Z, other.Z)},
+		coordinate{max(c.X, other.X), max(c.Y, other.Y), max(c.Z, other.Z)}
+}
+
+// volume returns the number of blocks in the cuboid between c and other,
+// both corners are inclusive. Regions too large to count return
+// math.MaxInt64.
+func (c coordinate) volume(other coordinate) int64 {
+	lo, hi := c.bounds(other)
+
+	v := int64(1)
+	for _, d := range []int64{int64(hi.X) - int64(lo.X), int64(hi.Y) - int64(lo.Y), int64(hi.Z) - int64(lo.Z)} {
+		// the distance wraps around when the corners are further apart
+		// than an int64 can hold
+		if d < 0 || d >= math.MaxInt64/v {
+			return math.MaxInt64
+		}
+
+		v *= d + 1
+	}
+
+	return v
+}
+
+// cuboid returns every coordinate in the cuboid between c and other.
+func (c coordinate) cuboid(other coordinate) []coordinate {
+	lo, hi := c.bounds(other)
+
+	coords := make([]coordinate, 0, c.volume(other))
+	for x := lo.X; x <= hi.X; x++ {
+		for y := lo.Y; y <= hi.Y; y++ {
+			for z := lo.Z; z <= hi.Z; z++ {
+				coords = append(coords, coordinate{x, y, z})
+			}
+		}
+	}
+
+	return coords
+}
+
+// rotate rotates c clockwise around origin by degrees, which must be a
+// multiple of 90, negative values rotate anti-clockwise.
+func (c coordinate) rotate(origin coordinate, degrees int) (coordinate, error) {
+	if degrees%90 != 0 {
+		return c, fmt.Errorf("invalid rotation %d, must be a multiple of 90", degrees)
+	}
+
+	dx, dz, err := rotateOffset(c.X-origin.X, c.Z-origin.Z, (degrees%360+360)%360)
+	if err != nil {
+		return c, err
+	}
+
+	return coordinate{origin.X + dx, c.Y, origin.Z + dz}, nil
+}
+
+// chunk returns the x and z coordinates of the 16x16 chunk containing c.
+func (c coordinate) chunk() (int, int) {
+	return floorDiv(c.X, chunkSize), floorDiv(c.Z, chunkSize)
+}
+
+// chunkSize is the width and length of a chunk in blocks.
+const chunkSize = 16
+
+// floorDiv divides a by b rounding towards negative infinity.
+func floorDiv(a, b int) int {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+
+	return q
+}
+
+// boxesIntersect returns true when the cuboid between a1 and a2 shares at
+// least one block with the cuboid between b1 and b2.
+func boxesIntersect(a1, a2, b1, b2 coordinate) bool {
+	aLo, aHi := a1.bounds(a2)
+	bLo, bHi := b1.bounds(b2)
+
+	return aLo.X <= bHi.X && bLo.X <= aHi.X &&
+		aLo.Y <= bHi.Y && bLo.Y <= aHi.Y &&
+		aLo.Z <= bHi.Z && bLo.Z <= aHi.Z
+}
+
+// onShell returns true when c lies on a face of the cuboid between a and b.
+func (c coordinate) onShell(a, b coordinate) bool {
+	lo, hi := a.bounds(b)
+	return c.X == lo.X || c.X == hi.X || c.Y == lo.Y || c.Y == hi.Y || c.Z == lo.Z || c.Z == hi.Z
+}
+
+// sortedCoordinates returns the keys of m ordered by x, y then z.
+func sortedCoordinates[T any](m map[coordinate]T) []coordinate {
+	coords := make([]coordinate, 0, len(m))
+	for c := range m {
+		coords = append(coords, c)
+	}
+
+	sort.Slice(coords, func(i, j int) bool {
+		a, b := coords[i], coords[j]
+		if a.X != b.X {
+			return a.X < b.X
+		}
+
+		if a.Y != b.Y {
+			return a.Y < b.Y
+		}
+
+		return a.Z < b.Z
+	})
+
+	return coords
+}
+
+// sampleCoordinates returns at most n keys of m spread evenly over the sorted
+// coordinates, the same sample is returned for the same input.
+func sampleCoordinates[T any](m map[coordinate]T, n int) []coordinate {
+	coords := sortedCoordinates(m)
+	if len(coords) <= n {
+		return coords
+	}
+
+	sample := make([]coordinate, 0, n)
+	for i := 0; i < n; i++ {
+		sample = append(sample, coords[i*len(coords)/n])
+	}
+
+	return sample
+}
+
+// intValue converts a number attribute to an int, fractional values are
+// truncated.
+func intValue(n types.Number) int {
+	if n.IsNull() || n.IsUnknown() {
+		return 0
+	}
+
+	v, _ := n.ValueBigFloat().Int64()
+	return int(v)
+}
+
+// numberValue converts an int to a number attribute.
+func numberValue(v int) types.Number {
+	return types.NumberValue(big.NewFloat(float64(v)))
+}
+
+// parseCoordinates parses a string in the form "x,y,z" into its components.
+func parseCoordinates(id string) (int, int, int, error) {
+	parts := strings.Split(id, ",")
+	if len(parts) != 3 {
+		return 0, 0, 0, fmt.Errorf("expected 3 coordinates, got %d", len(parts))
+	}
+
+	coords := make([]int, 3)
+	for i, p := range parts {
+		v, err := strconv.Atoi(strings.TrimSpace(p))
+		if err != nil {
+			return 0, 0, 0, fmt.Errorf("invalid coordinate %q: %s", p, err)
+		}
+
+		coords[i] = v
+	}
+
+	return coords[0], coords[1], coords[2], nil
+}
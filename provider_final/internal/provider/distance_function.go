@@ -0,0 +1,61 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"math"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var (
+	_ function.Function = DistanceFunction{}
+)
+
+func NewDistanceFunction() function.Function {
+	return DistanceFunction{}
+}
+
+type DistanceFunction struct{}
+
+func (r DistanceFunction) Metadata(_ context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "distance"
+}
+
+func (r DistanceFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Distance between two positions",
+		MarkdownDescription: "Returns the straight line distance in blocks between two positions",
+		Parameters: []function.Parameter{
+			function.ObjectParameter{
+				Name:                "a",
+				MarkdownDescription: "First position with `x`, `y` and `z` attributes",
+				AttributeTypes:      positionAttrTypes,
+			},
+			function.ObjectParameter{
+				Name:                "b",
+				MarkdownDescription: "Second position with `x`, `y` and `z` attributes",
+				AttributeTypes:      positionAttrTypes,
+			},
+		},
+		Return: function.Float64Return{},
+	}
+}
+
+func (r DistanceFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var a, b positionModel
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &a, &b))
+
+	if resp.Error != nil {
+		return
+	}
+
+	dx := float64(a.X - b.X)
+	dy := float64(a.Y - b.Y)
+	dz := float64(a.Z - b.Z)
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, math.Sqrt(dx*dx+dy*dy+dz*dz)))
+}
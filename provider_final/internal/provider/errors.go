@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/telemetry"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// APIError is returned by the client when the Minecraft API responds with a
+// status other than 200.
+type APIError struct {
+	// StatusCode is the HTTP status returned by the server.
+	StatusCode int
+	// Method is the HTTP method of the failed request.
+	Method string
+	// Endpoint is the API route of the failed request, e.g. /v1/block.
+	Endpoint string
+	// Message is the body returned by the server.
+	Message string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s %s: expected status 200, got status: %d, message: %s", e.Method, e.Endpoint, e.StatusCode, e.Message)
+}
+
+// IsNotFound returns true when err is an APIError for a 404 response.
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsUnauthorized returns true when err is an APIError for a 401 or 403
+// response, the server uses both when the API key is missing or invalid.
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized) || hasStatus(err, http.StatusForbidden)
+}
+
+// IsConflict returns true when err is an APIError for a 409 response.
+func IsConflict(err error) bool {
+	return hasStatus(err, http.StatusConflict)
+}
+
+// IsRateLimited returns true when err is an APIError for a 429 response.
+func IsRateLimited(err error) bool {
+	return hasStatus(err, http.StatusTooManyRequests)
+}
+
+func hasStatus(err error, status int) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == status
+	}
+
+	return false
+}
+
+// addClientError converts an error returned from the client into a
+// diagnostic, action describes what was being attempted, e.g. "read block". A
+// rejected API key is reported against the provider api_key attribute.
+func addClientError(diags *diag.Diagnostics, action string, err error) {
+	switch {
+	case IsUnauthorized(err):
+		diags.AddAttributeError(
+			path.Root("api_key"),
+			"API key rejected",
+			fmt.Sprintf("The Minecraft server rejected the API key while trying to %s. Check the provider 'api_key' attribute, the environment variable '%s' or the provider 'auth' block.\n\n%s", action, envAPIKey, err),
+		)
+	case IsNotFound(err):
+		diags.AddError(
+			"Not Found",
+			fmt.Sprintf("The Minecraft server could not find the requested item while trying to %s.\n\n%s", action, err),
+		)
+	case IsConflict(err):
+		diags.AddError(
+			"Conflict",
+			fmt.Sprintf("The Minecraft server reported a conflict while trying to %s.\n\n%s", action, err),
+		)
+	case IsRateLimited(err):
+		diags.AddError(
+			"Rate Limited",
+			fmt.Sprintf("The Minecraft server kept rejecting requests as too frequent while trying to %s. Lower the provider 'requests_per_second' or 'max_concurrent_requests' attributes.\n\n%s", action, err),
+		)
+	default:
+		diags.AddError("Client Error", fmt.Sprintf("Unable to %s, got error: %s", action, err))
+	}
+}
+
+// endOperation ends the span of a resource or data source operation, marking
+// it as failed when diags contains an error.
+func endOperation(span trace.Span, diags *diag.Diagnostics) {
+	telemetry.End(span, func() error {
+		if !diags.HasError() {
+			return nil
+		}
+
+		e := diags.Errors()[0]
+		return fmt.Errorf("%s: %s", e.Summary(), e.Detail())
+	})
+}
@@ -0,0 +1,495 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const (
+	fakeAPIKey      = "supertopsecret"
+	fakeAirMaterial = "minecraft:air"
+)
+
+// fakeMinecraftServer is an in-process implementation of the Minecraft API
+// backed by in-memory worlds, it allows the provider tests to run without
+// the jumppad environment.
+type fakeMinecraftServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	worlds   map[string]map[fakeCoord]fakeBlock
+	schemas  map[string]*fakeSchema
+	failures []*fakeFailure
+	requests map[string]int
+	nextID   int
+
+	// coreOnly makes the server respond 404 to the endpoints the provider
+	// uses beyond the core API, see disableExtensions.
+	coreOnly bool
+}
+
+type fakeCoord struct {
+	X, Y, Z int
+}
+
+// fakeBlock is a block in the fake world.
+type fakeBlock struct {
+	material string
+	state    map[string]string
+}
+
+// fakeSchema records a placed schema and the blocks it replaced so that it
+// can be undone.
+type fakeSchema struct {
+	world    string
+	details  schemaDetailsResponse
+	previous map[fakeCoord]fakeBlock
+}
+
+// fakeFailure causes the next count requests matching method and path
+// prefix to fail with status.
+type fakeFailure struct {
+	method string
+	prefix string
+	status int
+	count  int
+}
+
+// newFakeMinecraftServer starts a fake server which is closed when the test
+// completes.
+func newFakeMinecraftServer(t *testing.T) *fakeMinecraftServer {
+	t.Helper()
+
+	f := &fakeMinecraftServer{
+		worlds:   map[string]map[fakeCoord]fakeBlock{},
+		schemas:  map[string]*fakeSchema{},
+		requests: map[string]int{},
+	}
+
+	f.Server = httptest.NewServer(http.HandlerFunc(f.handle))
+	t.Cleanup(f.Close)
+
+	return f
+}
+
+// failNext makes the next count requests with the given method and path
+// prefix return status, e.g. failNext("GET", "/v1/block", 503, 2).
+func (f *fakeMinecraftServer) failNext(method, prefix string, status, count int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.failures = append(f.failures, &fakeFailure{method, prefix, status, count})
+}
+
+// disableExtensions makes the server behave like one that only implements
+// the core API: listing schemas, bulk region reads and batch block requests
+// respond with 404.
+func (f *fakeMinecraftServer) disableExtensions() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.coreOnly = true
+}
+
+// isExtension returns true when r uses an endpoint beyond the core API.
+func isExtension(r *http.Request) bool {
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/v1/schema":
+		return true
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/v1/blocks/"):
+		return true
+	case r.Method == http.MethodPost && (r.URL.Path == "/v1/blocks" || r.URL.Path == "/v1/blocks/delete"):
+		return true
+	}
+
+	return false
+}
+
+// requestCount returns the number of requests received with the given method
+// and path.
+func (f *fakeMinecraftServer) requestCount(method, path string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.requests[method+" "+path]
+}
+
+// setBlock sets the material at the given coordinates, simulating a change
+// made in game.
+func (f *fakeMinecraftServer) setBlock(x, y, z int, material string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.blocksLocked(defaultWorld)[fakeCoord{x, y, z}] = fakeBlock{material: material}
+}
+
+// material returns the material at the given coordinates.
+func (f *fakeMinecraftServer) material(x, y, z int) string {
+	return f.materialIn(defaultWorld, x, y, z)
+}
+
+// materialIn returns the material at the given coordinates in world.
+func (f *fakeMinecraftServer) materialIn(world string, x, y, z int) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.blockLocked(world, fakeCoord{x, y, z}).material
+}
+
+// state returns the block state properties at the given coordinates.
+func (f *fakeMinecraftServer) state(x, y, z int) map[string]string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.blockLocked(defaultWorld, fakeCoord{x, y, z}).state
+}
+
+// blocksLocked returns the blocks in world, creating the world when it does
+// not exist.
+func (f *fakeMinecraftServer) blocksLocked(world string) map[fakeCoord]fakeBlock {
+	blocks, ok := f.worlds[world]
+	if !ok {
+		blocks = map[fakeCoord]fakeBlock{}
+		f.worlds[world] = blocks
+	}
+
+	return blocks
+}
+
+func (f *fakeMinecraftServer) blockLocked(world string, c fakeCoord) fakeBlock {
+	if b, ok := f.worlds[world][c]; ok {
+		return b
+	}
+
+	return fakeBlock{material: fakeAirMaterial}
+}
+
+func (f *fakeMinecraftServer) handle(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get(authHeader) != fakeAPIKey {
+		http.Error(w, "invalid api key", http.StatusUnauthorized)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.requests[r.Method+" "+r.URL.Path]++
+
+	for _, fail := range f.failures {
+		if fail.count > 0 && fail.method == r.Method && strings.HasPrefix(r.URL.Path, fail.prefix) {
+			fail.count--
+			http.Error(w, "injected failure", fail.status)
+			return
+		}
+	}
+
+	if f.coreOnly && isExtension(r) {
+		http.NotFound(w, r)
+		return
+	}
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+	world := r.URL.Query().Get(worldParameter)
+	if world == "" {
+		world = defaultWorld
+	}
+
+	w.Header().Set(worldHeader, world)
+
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/v1/block":
+		f.handleCreateBlock(w, r, world)
+	case r.Method == http.MethodPost && r.URL.Path == "/v1/blocks":
+		f.handleCreateBlocks(w, r, world)
+	case r.Method == http.MethodPost && r.URL.Path == "/v1/blocks/delete":
+		f.handleDeleteBlocks(w, r, world)
+	case len(parts) == 5 && parts[1] == "block":
+		c, ok := parseFakeCoord(w, parts[2:5])
+		if !ok {
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			f.writeBlock(w, world, c)
+		case http.MethodDelete:
+			delete(f.blocksLocked(world), c)
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	case r.Method == http.MethodGet && len(parts) == 8 && parts[1] == "blocks":
+		start, ok := parseFakeCoord(w, parts[2:5])
+		if !ok {
+			return
+		}
+
+		end, ok := parseFakeCoord(w, parts[5:8])
+		if !ok {
+			return
+		}
+
+		f.writeBlocks(w, world, start, end)
+	case r.Method == http.MethodDelete && len(parts) == 4 && parts[1] == "schema" && parts[2] == "undo":
+		f.handleUndoSchema(w, parts[3])
+	case r.Method == http.MethodGet && len(parts) == 4 && parts[1] == "schema" && parts[2] == "details":
+		s, ok := f.schemas[parts[3]]
+		if !ok {
+			http.Error(w, "schema not found", http.StatusNotFound)
+			return
+		}
+
+		json.NewEncoder(w).Encode(s.details)
+	case r.Method == http.MethodGet && r.URL.Path == "/v1/schema":
+		f.writeSchemas(w, world)
+	case r.Method == http.MethodPost && len(parts) == 6 && parts[1] == "schema":
+		f.handleCreateSchema(w, r, world, parts[2:6])
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (f *fakeMinecraftServer) handleCreateBlock(w http.ResponseWriter, r *http.Request, world string) {
+	br := blockRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&br); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	c, err := f.placeLocked(world, br)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	f.writeBlock(w, world, c)
+}
+
+// handleCreateBlocks places every block of a batch, reporting the outcome of
+// each block.
+func (f *fakeMinecraftServer) handleCreateBlocks(w http.ResponseWriter, r *http.Request, world string) {
+	brs := []blockRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&brs); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	items := make([]blockBatchItem, len(brs))
+	for i, br := range brs {
+		c, err := f.placeLocked(world, br)
+		if err != nil {
+			items[i] = blockBatchItem{Status: http.StatusBadRequest, Error: err.Error()}
+			continue
+		}
+
+		b := f.blockResponseLocked(world, c)
+		items[i] = blockBatchItem{Status: http.StatusOK, Block: &b}
+	}
+
+	json.NewEncoder(w).Encode(items)
+}
+
+func (f *fakeMinecraftServer) handleDeleteBlocks(w http.ResponseWriter, r *http.Request, world string) {
+	positions := []blockPosition{}
+	if err := json.NewDecoder(r.Body).Decode(&positions); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	items := make([]blockBatchItem, len(positions))
+	for i, p := range positions {
+		delete(f.blocksLocked(world), fakeCoord{p.X, p.Y, p.Z})
+		items[i] = blockBatchItem{Status: http.StatusOK}
+	}
+
+	json.NewEncoder(w).Encode(items)
+}
+
+// placeLocked sets the block of the request, a material is required.
+func (f *fakeMinecraftServer) placeLocked(world string, br blockRequest) (fakeCoord, error) {
+	c := fakeCoord{br.X, br.Y, br.Z}
+
+	if br.Material == "" {
+		return c, fmt.Errorf("material is required")
+	}
+
+	state := map[string]string{}
+	for k, v := range br.State {
+		state[k] = v
+	}
+
+	if br.Facing != "" {
+		state["facing"] = br.Facing
+	}
+
+	if br.Half != "" {
+		state["half"] = br.Half
+	}
+
+	f.blocksLocked(world)[c] = fakeBlock{material: br.Material, state: state}
+
+	return c, nil
+}
+
+func (f *fakeMinecraftServer) writeBlock(w http.ResponseWriter, world string, c fakeCoord) {
+	json.NewEncoder(w).Encode(f.blockResponseLocked(world, c))
+}
+
+func (f *fakeMinecraftServer) blockResponseLocked(world string, c fakeCoord) blockResponse {
+	b := f.blockLocked(world, c)
+
+	return blockResponse{
+		ID:       fmt.Sprintf("%d_%d_%d", c.X, c.Y, c.Z),
+		X:        c.X,
+		Y:        c.Y,
+		Z:        c.Z,
+		Material: b.material,
+		State:    b.state,
+	}
+}
+
+func (f *fakeMinecraftServer) writeBlocks(w http.ResponseWriter, world string, start, end fakeCoord) {
+	blocks := []blockResponse{}
+
+	for x := start.X; x <= end.X; x++ {
+		for y := start.Y; y <= end.Y; y++ {
+			for z := start.Z; z <= end.Z; z++ {
+				b := f.blockLocked(world, fakeCoord{x, y, z})
+
+				blocks = append(blocks, blockResponse{
+					ID:       fmt.Sprintf("%d_%d_%d", x, y, z),
+					X:        x,
+					Y:        y,
+					Z:        z,
+					Material: b.material,
+					State:    b.state,
+				})
+			}
+		}
+	}
+
+	json.NewEncoder(w).Encode(blocks)
+}
+
+func (f *fakeMinecraftServer) handleCreateSchema(w http.ResponseWriter, r *http.Request, world string, params []string) {
+	values := make([]int, 4)
+	for i, p := range params {
+		v, err := strconv.Atoi(p)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid parameter %q", p), http.StatusBadRequest)
+			return
+		}
+
+		values[i] = v
+	}
+
+	origin := fakeCoord{values[0], values[1], values[2]}
+	rotation := values[3]
+
+	voxels, err := readFakeSchema(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s := &fakeSchema{world: world, previous: map[fakeCoord]fakeBlock{}}
+	blocks := f.blocksLocked(world)
+	first := true
+
+	for _, v := range voxels {
+		dx, dz, err := rotateOffset(v.X, v.Z, rotation)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		c := fakeCoord{origin.X + dx, origin.Y + v.Y, origin.Z + dz}
+
+		if _, ok := s.previous[c]; !ok {
+			s.previous[c] = f.blockLocked(world, c)
+		}
+
+		blocks[c] = fakeBlock{material: v.Material, state: v.state()}
+
+		if first {
+			s.details = schemaDetailsResponse{c.X, c.Y, c.Z, c.X, c.Y, c.Z}
+			first = false
+		}
+
+		s.details.StartX = min(s.details.StartX, c.X)
+		s.details.StartY = min(s.details.StartY, c.Y)
+		s.details.StartZ = min(s.details.StartZ, c.Z)
+		s.details.EndX = max(s.details.EndX, c.X)
+		s.details.EndY = max(s.details.EndY, c.Y)
+		s.details.EndZ = max(s.details.EndZ, c.Z)
+	}
+
+	f.nextID++
+	id := fmt.Sprintf("undo-%d", f.nextID)
+	f.schemas[id] = s
+
+	w.Write([]byte(id))
+}
+
+func (f *fakeMinecraftServer) writeSchemas(w http.ResponseWriter, world string) {
+	items := []schemaListItem{}
+	for id, s := range f.schemas {
+		if s.world != world {
+			continue
+		}
+
+		items = append(items, schemaListItem{ID: id, schemaDetailsResponse: s.details})
+	}
+
+	sort.Slice(items, func(i, j int) bool { return items[i].ID < items[j].ID })
+
+	json.NewEncoder(w).Encode(items)
+}
+
+func (f *fakeMinecraftServer) handleUndoSchema(w http.ResponseWriter, id string) {
+	s, ok := f.schemas[id]
+	if !ok {
+		http.Error(w, "schema not found", http.StatusNotFound)
+		return
+	}
+
+	blocks := f.blocksLocked(s.world)
+	for c, b := range s.previous {
+		blocks[c] = b
+	}
+
+	delete(f.schemas, id)
+}
+
+func parseFakeCoord(w http.ResponseWriter, parts []string) (fakeCoord, bool) {
+	values := make([]int, 3)
+	for i, p := range parts {
+		v, err := strconv.Atoi(p)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid coordinate %q", p), http.StatusBadRequest)
+			return fakeCoord{}, false
+		}
+
+		values[i] = v
+	}
+
+	return fakeCoord{values[0], values[1], values[2]}, true
+}
+
+// readFakeSchema reads the voxels from a schema zip.
+func readFakeSchema(r io.Reader) ([]voxel, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return readSchemaZip(data)
+}
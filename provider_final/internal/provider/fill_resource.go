@@ -0,0 +1,658 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/numberplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/telemetry"
+)
+
+const (
+	fillModeReplace = "replace"
+	fillModeHollow  = "hollow"
+	fillModeOutline = "outline"
+	fillModeKeep    = "keep"
+
+	// maxFillVolume matches the limit of the in game /fill command.
+	maxFillVolume = 32768
+
+	// fillSampleSize is the number of changed blocks checked for drift on
+	// every refresh.
+	fillSampleSize = 32
+
+	airMaterial = "minecraft:air"
+)
+
+var fillModes = []string{fillModeReplace, fillModeHollow, fillModeOutline, fillModeKeep}
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &FillResource{}
+var _ resource.ResourceWithValidateConfig = &FillResource{}
+var _ resource.ResourceWithModifyPlan = &FillResource{}
+
+func NewFillResource() resource.Resource {
+	return &FillResource{}
+}
+
+// FillResource defines the resource implementation.
+type FillResource struct {
+	minecraftClient *client
+}
+
+// FillResourceModel describes the resource data model.
+type FillResourceModel struct {
+	StartX        types.Number `tfsdk:"start_x"`
+	StartY        types.Number `tfsdk:"start_y"`
+	StartZ        types.Number `tfsdk:"start_z"`
+	EndX          types.Number `tfsdk:"end_x"`
+	EndY          types.Number `tfsdk:"end_y"`
+	EndZ          types.Number `tfsdk:"end_z"`
+	Material      types.String `tfsdk:"material"`
+	State         types.Map    `tfsdk:"state"`
+	Mode          types.String `tfsdk:"mode"`
+	ChangedBlocks types.List   `tfsdk:"changed_blocks"`
+	World         types.String `tfsdk:"world"`
+	Id            types.String `tfsdk:"id"`
+}
+
+// FillBlockModel describes a single block changed by the fill.
+type FillBlockModel struct {
+	X                types.Number `tfsdk:"x"`
+	Y                types.Number `tfsdk:"y"`
+	Z                types.Number `tfsdk:"z"`
+	Material         types.String `tfsdk:"material"`
+	PreviousMaterial types.String `tfsdk:"previous_material"`
+	PreviousState    types.Map    `tfsdk:"previous_state"`
+}
+
+var fillBlockAttrTypes = map[string]attr.Type{
+	"x":                 types.NumberType,
+	"y":                 types.NumberType,
+	"z":                 types.NumberType,
+	"material":          types.StringType,
+	"previous_material": types.StringType,
+	"previous_state":    types.MapType{ElemType: types.StringType},
+}
+
+// fillBlock is the internal representation of a changed block.
+type fillBlock struct {
+	material         string
+	previousMaterial string
+	previousState    map[string]string
+}
+
+func (r *FillResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_fill"
+}
+
+func (r *FillResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	corner := func(description string) schema.NumberAttribute {
+		return schema.NumberAttribute{
+			MarkdownDescription: description,
+			Required:            true,
+			PlanModifiers: []planmodifier.Number{
+				numberplanmodifier.RequiresReplace(),
+			},
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Fills the cuboid between two corners with a material, the blocks that were replaced are restored on destroy",
+
+		Attributes: map[string]schema.Attribute{
+			"start_x": corner("X coordinate of the first corner"),
+			"start_y": corner("Y coordinate of the first corner"),
+			"start_z": corner("Z coordinate of the first corner"),
+			"end_x":   corner("X coordinate of the opposite corner"),
+			"end_y":   corner("Y coordinate of the opposite corner"),
+			"end_z":   corner("Z coordinate of the opposite corner"),
+			"material": schema.StringAttribute{
+				MarkdownDescription: "Material used to fill the region, e.g. `minecraft:stone`",
+				Required:            true,
+				Validators: []validator.String{
+					materialValidator{},
+				},
+			},
+			"state": schema.MapAttribute{
+				MarkdownDescription: "Block state properties applied to every placed block, such as `facing` or `axis`",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.Map{
+					blockStateValidator{},
+				},
+			},
+			"mode": schema.StringAttribute{
+				MarkdownDescription: "How the region is filled, one of `replace` (every block), `hollow` (outer shell, interior cleared to air), `outline` (outer shell only) or `keep` (only air blocks). Defaults to `replace`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(fillModeReplace),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"world": schema.StringAttribute{
+				MarkdownDescription: "World the region is filled in, e.g. `minecraft:the_nether`. Defaults to the provider `world`, changing it replaces the fill.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					worldValidator{},
+				},
+			},
+			"changed_blocks": schema.ListNestedAttribute{
+				MarkdownDescription: "Blocks changed by the fill and the material they had before it was applied",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"x": schema.NumberAttribute{
+							MarkdownDescription: "X coordinate of the block",
+							Computed:            true,
+						},
+						"y": schema.NumberAttribute{
+							MarkdownDescription: "Y coordinate of the block",
+							Computed:            true,
+						},
+						"z": schema.NumberAttribute{
+							MarkdownDescription: "Z coordinate of the block",
+							Computed:            true,
+						},
+						"material": schema.StringAttribute{
+							MarkdownDescription: "Material placed by the fill",
+							Computed:            true,
+						},
+						"previous_material": schema.StringAttribute{
+							MarkdownDescription: "Material of the block before the fill, restored on destroy",
+							Computed:            true,
+						},
+						"previous_state": schema.MapAttribute{
+							MarkdownDescription: "Block state properties of the block before the fill, restored on destroy",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Fill identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *FillResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data FillResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.Mode.IsNull() && !data.Mode.IsUnknown() && !isFillMode(data.Mode.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("mode"),
+			"Invalid Fill Mode",
+			fmt.Sprintf("Mode %q is not supported, must be one of: %v", data.Mode.ValueString(), fillModes),
+		)
+	}
+
+	for _, n := range []types.Number{data.StartX, data.StartY, data.StartZ, data.EndX, data.EndY, data.EndZ} {
+		if n.IsUnknown() {
+			return
+		}
+	}
+
+	checkFillVolume(data.start(), data.end(), &resp.Diagnostics)
+}
+
+// checkFillVolume adds an error when the region between start and end is
+// larger than a single resource can fill.
+func checkFillVolume(start, end coordinate, diags *diag.Diagnostics) {
+	if v := start.volume(end); v > maxFillVolume {
+		diags.AddError(
+			"Fill Region Too Large",
+			fmt.Sprintf("The region contains %d blocks, the maximum that can be filled by a single resource is %d", v, maxFillVolume),
+		)
+	}
+}
+
+// ModifyPlan plans the world of the fill. When Read has found blocks that
+// were changed in game the changed blocks are planned as unknown, so that
+// Terraform plans an update which fills the region again.
+func (r *FillResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to do when the resource is being destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	planWorld(ctx, r.minecraftClient, req, resp)
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan, state FillResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() || !plan.known() {
+		return
+	}
+
+	changed, diags := state.changedBlocks(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, c := range sortedCoordinates(changed) {
+		if changed[c].material != plan.material(c) {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("changed_blocks"), types.ListUnknown(types.ObjectType{AttrTypes: fillBlockAttrTypes}))...)
+			return
+		}
+	}
+}
+
+func (r *FillResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.minecraftClient = client
+}
+
+func (r *FillResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := telemetry.Start(ctx, "minecraft_fill.Create")
+	defer endOperation(span, &resp.Diagnostics)
+
+	var data FillResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.World = r.minecraftClient.resolveWorld(data.World)
+
+	// the volume is not checked by ValidateConfig when a corner is unknown
+	checkFillVolume(data.start(), data.end(), &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	changed := map[coordinate]fillBlock{}
+	err := r.fill(ctx, data, changed, false)
+
+	// always record what has been changed so that a partial fill can be
+	// cleaned up by destroy
+	data.Id = types.StringValue(worldID(data.World.ValueString(), fmt.Sprintf("%s:%s", data.start().String(), data.end().String())))
+	resp.Diagnostics.Append(data.setChangedBlocks(ctx, changed)...)
+
+	if err != nil {
+		addClientError(&resp.Diagnostics, "fill region", err)
+	}
+
+	tflog.Trace(ctx, "filled a region", map[string]interface{}{
+		"changed": len(changed),
+	})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FillResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := telemetry.Start(ctx, "minecraft_fill.Read")
+	defer endOperation(span, &resp.Diagnostics)
+
+	var data FillResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	changed, diags := data.changedBlocks(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.World = types.StringValue(worldValue(data.World))
+
+	// checking every block is too slow for large regions, instead check an
+	// evenly spread sample of the blocks that were changed
+	drifted := false
+	for _, c := range sampleCoordinates(changed, fillSampleSize) {
+		block, err := r.minecraftClient.getBlock(ctx, data.World.ValueString(), c.X, c.Y, c.Z)
+		if err != nil {
+			addClientError(&resp.Diagnostics, "read block", err)
+			return
+		}
+
+		if block.Material != changed[c].material {
+			tflog.Debug(ctx, "fill has drifted", map[string]interface{}{
+				"coordinate": c.String(),
+				"expected":   changed[c].material,
+				"actual":     block.Material,
+			})
+
+			// record the material found in game, ModifyPlan plans an update
+			// which fills the region again
+			b := changed[c]
+			b.material = block.Material
+			changed[c] = b
+			drifted = true
+		}
+	}
+
+	if drifted {
+		resp.Diagnostics.Append(data.setChangedBlocks(ctx, changed)...)
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FillResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := telemetry.Start(ctx, "minecraft_fill.Update")
+	defer endOperation(span, &resp.Diagnostics)
+
+	var data, state FillResourceModel
+
+	// Read Terraform plan and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the corners and mode force replacement, only the material can change,
+	// keep the original materials so destroy restores the terrain
+	changed, diags := state.changedBlocks(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.fill(ctx, data, changed, true)
+	resp.Diagnostics.Append(data.setChangedBlocks(ctx, changed)...)
+
+	if err != nil {
+		addClientError(&resp.Diagnostics, "fill region", err)
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FillResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := telemetry.Start(ctx, "minecraft_fill.Delete")
+	defer endOperation(span, &resp.Diagnostics)
+
+	var data FillResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	changed, diags := data.changedBlocks(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	restore := []blockRequest{}
+	for _, c := range sortedCoordinates(changed) {
+		b := changed[c]
+
+		br := blockRequest{X: c.X, Y: c.Y, Z: c.Z, Material: b.previousMaterial}
+		br.setState(b.previousState)
+
+		restore = append(restore, br)
+	}
+
+	for _, res := range r.minecraftClient.placeBlocks(ctx, worldValue(data.World), restore) {
+		// restoring air to a block that has already been removed in game
+		// returns not found
+		if res.Err != nil && !IsNotFound(res.Err) {
+			addClientError(&resp.Diagnostics, "restore block", res.Err)
+			return
+		}
+	}
+}
+
+// fill places the material in the region according to the mode, changed is
+// updated with every block that is modified and the material it had before
+// it was first changed. When replaceTracked is set blocks that have already
+// been changed are placed again so that updated block state is applied. The
+// blocks are placed in batches, a block that fails is not recorded and the
+// first failure is returned.
+func (r *FillResource) fill(ctx context.Context, data FillResourceModel, changed map[coordinate]fillBlock, replaceTracked bool) error {
+	start, end := data.start(), data.end()
+	world := worldValue(data.World)
+	mode := data.Mode.ValueString()
+	material := data.Material.ValueString()
+
+	state, diags := blockStateFromMap(ctx, data.State)
+	if diags.HasError() {
+		return fmt.Errorf("unable to read block state: %v", diags)
+	}
+
+	coords := start.cuboid(end)
+
+	// read the blocks the fill does not track yet in a single pass, the
+	// previous material is recorded before they are first changed
+	var region map[coordinate]*blockResponse
+	for _, c := range coords {
+		if _, tracked := changed[c]; tracked || (mode == fillModeOutline && !c.onShell(start, end)) {
+			continue
+		}
+
+		var err error
+		region, err = r.minecraftClient.getRegion(ctx, world, start, end, defaultRegionWorkers)
+		if err != nil {
+			return err
+		}
+
+		break
+	}
+
+	var pending []coordinate
+	var requests []blockRequest
+	placed := map[coordinate]fillBlock{}
+
+	for _, c := range coords {
+		onShell := c.onShell(start, end)
+
+		desired := material
+		switch {
+		case mode == fillModeHollow && !onShell:
+			desired = airMaterial
+		case mode == fillModeOutline && !onShell:
+			continue
+		}
+
+		prev, tracked := changed[c]
+
+		current := prev.material
+		if !tracked {
+			block, ok := region[c]
+			if !ok {
+				return fmt.Errorf("unable to read block: block %s is missing from the region", c)
+			}
+
+			current = block.Material
+			prev.previousMaterial = block.Material
+			prev.previousState = block.blockState()
+		}
+
+		// keep only fills blocks that were air before the fill
+		if mode == fillModeKeep && prev.previousMaterial != airMaterial {
+			continue
+		}
+
+		if current == desired && !tracked {
+			continue
+		}
+
+		b := fillBlock{material: desired, previousMaterial: prev.previousMaterial, previousState: prev.previousState}
+
+		if current != desired || (tracked && replaceTracked) {
+			br := blockRequest{X: c.X, Y: c.Y, Z: c.Z, Material: desired}
+			if desired != airMaterial {
+				br.setState(state)
+			}
+
+			pending = append(pending, c)
+			requests = append(requests, br)
+			placed[c] = b
+
+			continue
+		}
+
+		changed[c] = b
+	}
+
+	var firstErr error
+	for i, res := range r.minecraftClient.placeBlocks(ctx, world, requests) {
+		if res.Err != nil {
+			if firstErr == nil {
+				firstErr = res.Err
+			}
+
+			continue
+		}
+
+		changed[pending[i]] = placed[pending[i]]
+	}
+
+	return firstErr
+}
+
+// known returns true when the corners, material and mode are known.
+func (m FillResourceModel) known() bool {
+	for _, v := range []attr.Value{m.StartX, m.StartY, m.StartZ, m.EndX, m.EndY, m.EndZ, m.Material, m.Mode} {
+		if v.IsUnknown() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// material returns the material the fill places at c, a block inside a
+// hollow fill is cleared to air.
+func (m FillResourceModel) material(c coordinate) string {
+	if m.Mode.ValueString() == fillModeHollow && !c.onShell(m.start(), m.end()) {
+		return airMaterial
+	}
+
+	return m.Material.ValueString()
+}
+
+func (m FillResourceModel) start() coordinate {
+	return coordinate{intValue(m.StartX), intValue(m.StartY), intValue(m.StartZ)}
+}
+
+func (m FillResourceModel) end() coordinate {
+	return coordinate{intValue(m.EndX), intValue(m.EndY), intValue(m.EndZ)}
+}
+
+func (m FillResourceModel) changedBlocks(ctx context.Context) (map[coordinate]fillBlock, diag.Diagnostics) {
+	blocks := []FillBlockModel{}
+	diags := m.ChangedBlocks.ElementsAs(ctx, &blocks, false)
+
+	changed := map[coordinate]fillBlock{}
+	for _, b := range blocks {
+		c := coordinate{intValue(b.X), intValue(b.Y), intValue(b.Z)}
+
+		state, d := blockStateFromMap(ctx, b.PreviousState)
+		diags.Append(d...)
+
+		changed[c] = fillBlock{material: b.Material.ValueString(), previousMaterial: b.PreviousMaterial.ValueString(), previousState: state}
+	}
+
+	return changed, diags
+}
+
+func (m *FillResourceModel) setChangedBlocks(ctx context.Context, changed map[coordinate]fillBlock) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	blocks := []attr.Value{}
+	for _, c := range sortedCoordinates(changed) {
+		b := changed[c]
+
+		state, d := types.MapValueFrom(ctx, types.StringType, b.previousState)
+		diags.Append(d...)
+
+		blocks = append(blocks, types.ObjectValueMust(fillBlockAttrTypes, map[string]attr.Value{
+			"x":                 numberValue(c.X),
+			"y":                 numberValue(c.Y),
+			"z":                 numberValue(c.Z),
+			"material":          types.StringValue(b.material),
+			"previous_material": types.StringValue(b.previousMaterial),
+			"previous_state":    state,
+		}))
+	}
+
+	list, d := types.ListValue(types.ObjectType{AttrTypes: fillBlockAttrTypes}, blocks)
+	diags.Append(d...)
+	m.ChangedBlocks = list
+
+	return diags
+}
+
+func isFillMode(mode string) bool {
+	for _, m := range fillModes {
+		if m == mode {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,244 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccFillResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckFakeBlock(-1270, 23, 290, "minecraft:air"),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccFillResourceConfig("minecraft:stone", "hollow"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("minecraft_fill.box", "mode", "hollow"),
+					resource.TestCheckResourceAttr("minecraft_fill.box", "changed_blocks.#", "26"),
+					testAccCheckFakeBlock(-1270, 23, 290, "minecraft:stone"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccFillResourceConfig("minecraft:glass", "hollow"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("minecraft_fill.box", "material", "minecraft:glass"),
+					resource.TestCheckResourceAttr("minecraft_fill.box", "changed_blocks.0.previous_material", "minecraft:air"),
+					testAccCheckFakeBlock(-1270, 23, 290, "minecraft:glass"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccFillResourceDrift(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// the interior of the hollow fill is cleared to air
+			{
+				PreConfig: func() {
+					if testAccServer != nil {
+						testAccServer.setBlock(-1270, 24, 290, "minecraft:dirt")
+					}
+				},
+				Config: testAccFillResourceConfig("minecraft:stone", "hollow"),
+				Check:  testAccCheckFakeBlock(-1270, 24, 290, "minecraft:air"),
+			},
+			// Drift testing, the interior is filled in game with the same
+			// material as the fill and Terraform clears it again
+			{
+				PreConfig: func() {
+					if testAccServer != nil {
+						testAccServer.setBlock(-1270, 24, 290, "minecraft:stone")
+					}
+				},
+				Config: testAccFillResourceConfig("minecraft:stone", "hollow"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("minecraft_fill.box", "material", "minecraft:stone"),
+					testAccCheckFakeBlock(-1270, 24, 290, "minecraft:air"),
+				),
+			},
+		},
+	})
+}
+
+func TestFillResourceBatchesBlocks(t *testing.T) {
+	srv := newFakeMinecraftServer(t)
+	r := &FillResource{minecraftClient: newClient(srv.URL, fakeAPIKey, testClientOptions())}
+	ctx := context.Background()
+
+	data := FillResourceModel{
+		StartX:   numberValue(0),
+		StartY:   numberValue(0),
+		StartZ:   numberValue(0),
+		EndX:     numberValue(4),
+		EndY:     numberValue(4),
+		EndZ:     numberValue(4),
+		Material: types.StringValue("minecraft:stone"),
+		State:    types.MapNull(types.StringType),
+		Mode:     types.StringValue(fillModeReplace),
+		World:    types.StringValue(defaultWorld),
+	}
+
+	changed := map[coordinate]fillBlock{}
+	if err := r.fill(ctx, data, changed, false); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(changed) != 125 || srv.material(2, 2, 2) != "minecraft:stone" {
+		t.Fatalf("expected the region to be filled, got %d changed blocks", len(changed))
+	}
+
+	// the region is read with a single request before it is filled
+	if n := srv.requestCount(http.MethodGet, "/v1/blocks/0/0/0/4/4/4"); n != 1 {
+		t.Fatalf("expected a single region request, got %d", n)
+	}
+
+	// 125 blocks are sent in two batches
+	if n := srv.requestCount(http.MethodPost, "/v1/blocks"); n != 2 {
+		t.Fatalf("expected 2 batch requests, got %d", n)
+	}
+
+	// hollowing the fill removes the inside with a single request
+	data.Mode = types.StringValue(fillModeHollow)
+	if err := r.fill(ctx, data, changed, false); err != nil {
+		t.Fatal(err)
+	}
+
+	if srv.material(2, 2, 2) != fakeAirMaterial || changed[coordinate{2, 2, 2}].material != airMaterial {
+		t.Fatal("expected the inside of the fill to be removed")
+	}
+
+	if n := srv.requestCount(http.MethodPost, "/v1/blocks/delete"); n != 1 {
+		t.Fatalf("expected a single delete request, got %d", n)
+	}
+
+	if n := srv.requestCount(http.MethodPost, "/v1/block"); n != 0 {
+		t.Fatalf("expected no single block requests, got %d", n)
+	}
+}
+
+func TestFillResourceModelMaterial(t *testing.T) {
+	data := FillResourceModel{
+		StartX:   numberValue(0),
+		StartY:   numberValue(0),
+		StartZ:   numberValue(0),
+		EndX:     numberValue(2),
+		EndY:     numberValue(2),
+		EndZ:     numberValue(2),
+		Material: types.StringValue("minecraft:stone"),
+		Mode:     types.StringValue(fillModeHollow),
+	}
+
+	if got := data.material(coordinate{0, 1, 1}); got != "minecraft:stone" {
+		t.Fatalf("expected the shell to be minecraft:stone, got %s", got)
+	}
+
+	// drift inside a hollow fill is found even when the material in game is
+	// the configured material
+	if got := data.material(coordinate{1, 1, 1}); got != airMaterial {
+		t.Fatalf("expected the inside to be %s, got %s", airMaterial, got)
+	}
+
+	data.Mode = types.StringValue(fillModeReplace)
+	if got := data.material(coordinate{1, 1, 1}); got != "minecraft:stone" {
+		t.Fatalf("expected the inside of a replace fill to be minecraft:stone, got %s", got)
+	}
+}
+
+func TestFillResourceWithoutBatchEndpoints(t *testing.T) {
+	srv := newFakeMinecraftServer(t)
+	srv.disableExtensions()
+
+	r := &FillResource{minecraftClient: newClient(srv.URL, fakeAPIKey, testClientOptions())}
+
+	data := FillResourceModel{
+		StartX:   numberValue(0),
+		StartY:   numberValue(0),
+		StartZ:   numberValue(0),
+		EndX:     numberValue(2),
+		EndY:     numberValue(2),
+		EndZ:     numberValue(2),
+		Material: types.StringValue("minecraft:stone"),
+		State:    types.MapNull(types.StringType),
+		Mode:     types.StringValue(fillModeReplace),
+		World:    types.StringValue(defaultWorld),
+	}
+
+	changed := map[coordinate]fillBlock{}
+	if err := r.fill(context.Background(), data, changed, false); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(changed) != 27 || srv.material(1, 1, 1) != "minecraft:stone" {
+		t.Fatalf("expected the region to be filled, got %d changed blocks", len(changed))
+	}
+
+	// the batch endpoint is tried once before each block is sent on its own
+	if n := srv.requestCount(http.MethodPost, "/v1/blocks"); n != 1 {
+		t.Fatalf("expected a single batch request, got %d", n)
+	}
+
+	if n := srv.requestCount(http.MethodPost, "/v1/block"); n != 27 {
+		t.Fatalf("expected a request for each block, got %d", n)
+	}
+}
+
+func TestCoordinateCuboid(t *testing.T) {
+	start := coordinate{1, 1, 1}
+	end := coordinate{-1, -1, -1}
+
+	if v := start.volume(end); v != 27 {
+		t.Fatalf("expected volume 27, got %d", v)
+	}
+
+	if v := (coordinate{math.MinInt, 0, 0}).volume(coordinate{math.MaxInt, 0, 0}); v != math.MaxInt64 {
+		t.Fatalf("expected the volume of a region wider than an int to saturate, got %d", v)
+	}
+
+	if v := (coordinate{0, 0, 0}).volume(coordinate{1 << 30, 1 << 30, 1 << 30}); v != math.MaxInt64 {
+		t.Fatalf("expected the volume of a huge region to saturate, got %d", v)
+	}
+
+	shell := 0
+	for _, c := range start.cuboid(end) {
+		if c.onShell(start, end) {
+			shell++
+		}
+	}
+
+	if shell != 26 {
+		t.Fatalf("expected 26 blocks on the shell, got %d", shell)
+	}
+
+	sample := sampleCoordinates(map[coordinate]bool{{0, 0, 0}: true, {1, 0, 0}: true, {2, 0, 0}: true}, 2)
+	if len(sample) != 2 || sample[0] != (coordinate{0, 0, 0}) {
+		t.Fatalf("unexpected sample: %v", sample)
+	}
+}
+
+// testAccFillResourceConfig fills a 3x3x3 cube with its center at -1270,24,290.
+func testAccFillResourceConfig(material, mode string) string {
+	return fmt.Sprintf(`
+  resource "minecraft_fill" "box" {
+	  start_x = -1271
+	  start_y = 23
+	  start_z = 289
+	  end_x = -1269
+	  end_y = 25
+	  end_z = 291
+	  material = %q
+	  mode = %q
+	}
+  `, material, mode)
+}
@@ -0,0 +1,208 @@
+package provider
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// identifierPattern matches a namespaced Minecraft identifier such as
+// minecraft:oak_planks.
+var identifierPattern = regexp.MustCompile(`^[a-z0-9_.-]+:[a-z0-9_./-]+$`)
+
+// minecraftNamespace is the namespace of the blocks in the catalog, materials
+// in any other namespace are added by mods and are not checked.
+const minecraftNamespace = "minecraft"
+
+// Tags applied to the materials in the catalog.
+const (
+	materialTagAir         = "air"
+	materialTagLiquid      = "liquid"
+	materialTagSolid       = "solid"
+	materialTagTransparent = "transparent"
+	materialTagGravity     = "gravity"
+)
+
+var materialTags = []string{
+	materialTagAir,
+	materialTagGravity,
+	materialTagLiquid,
+	materialTagSolid,
+	materialTagTransparent,
+}
+
+// materialsJSON is the catalog of blocks for the game version supported by
+// the provider.
+//
+//go:embed materials.json
+var materialsJSON []byte
+
+type materialInfo struct {
+	ID   string   `json:"id"`
+	Tags []string `json:"tags"`
+}
+
+func (m materialInfo) hasTag(tag string) bool {
+	for _, t := range m.Tags {
+		if t == tag {
+			return true
+		}
+	}
+
+	return false
+}
+
+type materialCatalog struct {
+	Version   string         `json:"version"`
+	Materials []materialInfo `json:"materials"`
+
+	index map[string]materialInfo
+}
+
+// materials is the catalog loaded from materialsJSON, sorted by id.
+var materials = mustLoadMaterialCatalog(materialsJSON)
+
+func mustLoadMaterialCatalog(data []byte) *materialCatalog {
+	c := &materialCatalog{}
+	if err := json.Unmarshal(data, c); err != nil {
+		panic(fmt.Sprintf("invalid materials catalog: %s", err))
+	}
+
+	sort.Slice(c.Materials, func(i, j int) bool { return c.Materials[i].ID < c.Materials[j].ID })
+
+	c.index = make(map[string]materialInfo, len(c.Materials))
+	for _, m := range c.Materials {
+		c.index[m.ID] = m
+	}
+
+	return c
+}
+
+func (c *materialCatalog) lookup(material string) (materialInfo, bool) {
+	m, ok := c.index[material]
+	return m, ok
+}
+
+// withTag returns the materials that have tag, or every material when tag is
+// empty.
+func (c *materialCatalog) withTag(tag string) []materialInfo {
+	if tag == "" {
+		return c.Materials
+	}
+
+	list := []materialInfo{}
+	for _, m := range c.Materials {
+		if m.hasTag(tag) {
+			list = append(list, m)
+		}
+	}
+
+	return list
+}
+
+// suggest returns the material in the catalog closest to material, or an
+// empty string when nothing is close enough to be a likely typo.
+func (c *materialCatalog) suggest(material string) string {
+	name := material
+	if _, after, ok := strings.Cut(material, ":"); ok {
+		name = after
+	}
+
+	best := ""
+	bestDistance := len(name)/3 + 2
+
+	for _, m := range c.Materials {
+		_, candidate, _ := strings.Cut(m.ID, ":")
+
+		if d := levenshtein(name, candidate); d < bestDistance {
+			best, bestDistance = m.ID, d
+		}
+	}
+
+	return best
+}
+
+// levenshtein returns the number of single character edits needed to change
+// a into b.
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
+
+// validateMaterial returns an error when material is not a namespaced
+// identifier, or is in the minecraft namespace but not in the catalog.
+func validateMaterial(material string) error {
+	if !identifierPattern.MatchString(material) {
+		msg := fmt.Sprintf("invalid material %q, must be a namespaced identifier such as minecraft:stone", material)
+		if s := materials.suggest(material); s != "" && !strings.Contains(material, ":") {
+			msg += fmt.Sprintf(", did you mean %q?", s)
+		}
+
+		return errors.New(msg)
+	}
+
+	namespace, _, _ := strings.Cut(material, ":")
+	if namespace != minecraftNamespace {
+		return nil
+	}
+
+	if _, ok := materials.lookup(material); ok {
+		return nil
+	}
+
+	msg := fmt.Sprintf("unknown material %q, it is not a block in Minecraft %s", material, materials.Version)
+	if s := materials.suggest(material); s != "" {
+		msg += fmt.Sprintf(", did you mean %q?", s)
+	}
+
+	return errors.New(msg)
+}
+
+// materialValidator validates a string attribute containing a material.
+type materialValidator struct{}
+
+func (v materialValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("material must be a namespaced identifier and blocks in the minecraft namespace must exist in Minecraft %s", materials.Version)
+}
+
+func (v materialValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v materialValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if err := validateMaterial(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Material", err.Error())
+	}
+}
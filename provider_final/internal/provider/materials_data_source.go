@@ -0,0 +1,163 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &MaterialsDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &MaterialsDataSource{}
+
+func NewMaterialsDataSource() datasource.DataSource {
+	return &MaterialsDataSource{}
+}
+
+// MaterialsDataSource defines the data source implementation, it reads the
+// catalog bundled with the provider and does not call the server.
+type MaterialsDataSource struct{}
+
+// MaterialsDataSourceModel describes the data source data model.
+type MaterialsDataSourceModel struct {
+	Tag       types.String `tfsdk:"tag"`
+	Version   types.String `tfsdk:"version"`
+	Materials types.List   `tfsdk:"materials"`
+	Ids       types.List   `tfsdk:"ids"`
+	Id        types.String `tfsdk:"id"`
+}
+
+var materialAttrTypes = map[string]attr.Type{
+	"id":   types.StringType,
+	"tags": types.ListType{ElemType: types.StringType},
+}
+
+func (d *MaterialsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_materials"
+}
+
+func (d *MaterialsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: fmt.Sprintf("Lists the blocks in Minecraft %s that can be used as a material", materials.Version),
+
+		Attributes: map[string]schema.Attribute{
+			"tag": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Only list materials with this tag, one of `%s`", strings.Join(materialTags, "`, `")),
+				Optional:            true,
+			},
+			"version": schema.StringAttribute{
+				MarkdownDescription: "Minecraft version the catalog was built for",
+				Computed:            true,
+			},
+			"materials": schema.ListNestedAttribute{
+				MarkdownDescription: "Materials ordered by identifier",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "Namespaced identifier of the material, e.g. `minecraft:stone`",
+							Computed:            true,
+						},
+						"tags": schema.ListAttribute{
+							MarkdownDescription: "Categories the material belongs to, such as `solid`, `transparent` or `gravity` for blocks that fall",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"ids": schema.ListAttribute{
+				MarkdownDescription: "Identifiers of the materials",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Data source identifier",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *MaterialsDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var data MaterialsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() || data.Tag.IsNull() || data.Tag.IsUnknown() {
+		return
+	}
+
+	for _, t := range materialTags {
+		if t == data.Tag.ValueString() {
+			return
+		}
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		path.Root("tag"),
+		"Invalid Material Tag",
+		fmt.Sprintf("Unknown tag %q, must be one of: %s", data.Tag.ValueString(), strings.Join(materialTags, ", ")),
+	)
+}
+
+func (d *MaterialsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data MaterialsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tag := data.Tag.ValueString()
+	list := materials.withTag(tag)
+
+	values := make([]attr.Value, 0, len(list))
+	ids := make([]string, 0, len(list))
+
+	for _, m := range list {
+		tags, diags := types.ListValueFrom(ctx, types.StringType, m.Tags)
+		resp.Diagnostics.Append(diags...)
+
+		values = append(values, types.ObjectValueMust(materialAttrTypes, map[string]attr.Value{
+			"id":   types.StringValue(m.ID),
+			"tags": tags,
+		}))
+		ids = append(ids, m.ID)
+	}
+
+	materialList, diags := types.ListValue(types.ObjectType{AttrTypes: materialAttrTypes}, values)
+	resp.Diagnostics.Append(diags...)
+
+	idList, diags := types.ListValueFrom(ctx, types.StringType, ids)
+	resp.Diagnostics.Append(diags...)
+
+	data.Version = types.StringValue(materials.Version)
+	data.Materials = materialList
+	data.Ids = idList
+
+	data.Id = types.StringValue(materials.Version)
+	if tag != "" {
+		data.Id = types.StringValue(fmt.Sprintf("%s:%s", materials.Version, tag))
+	}
+
+	tflog.Trace(ctx, "read a data source", map[string]interface{}{
+		"materials": len(list),
+	})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,40 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccMaterialsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccMaterialsDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.minecraft_materials.all", "version", "1.18.2"),
+					resource.TestCheckTypeSetElemAttr("data.minecraft_materials.all", "ids.*", "minecraft:stone"),
+					resource.TestCheckTypeSetElemAttr("data.minecraft_materials.falling", "ids.*", "minecraft:gravel"),
+					resource.TestCheckResourceAttr("data.minecraft_materials.falling", "id", "1.18.2:gravity"),
+				),
+			},
+			// Unknown tags are rejected
+			{
+				Config:      `data "minecraft_materials" "bad" { tag = "shiny" }`,
+				ExpectError: regexp.MustCompile("Invalid Material Tag"),
+			},
+		},
+	})
+}
+
+const testAccMaterialsDataSourceConfig = `
+data "minecraft_materials" "all" {}
+
+data "minecraft_materials" "falling" {
+  tag = "gravity"
+}
+`
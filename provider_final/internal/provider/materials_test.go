@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateMaterial(t *testing.T) {
+	for _, m := range []string{"minecraft:stone", "minecraft:oak_stairs", "mymod:blue_block.v2"} {
+		if err := validateMaterial(m); err != nil {
+			t.Fatalf("expected %q to be valid: %s", m, err)
+		}
+	}
+
+	for _, m := range []string{"", "stone", "minecraft:Stone", "minecraft:", "minecraft:not_a_block"} {
+		if err := validateMaterial(m); err == nil {
+			t.Fatalf("expected %q to be invalid", m)
+		}
+	}
+}
+
+func TestValidateMaterialSuggestion(t *testing.T) {
+	for material, want := range map[string]string{
+		"minecraft:stne":        `did you mean "minecraft:stone"?`,
+		"minecraft:oak_plank":   `did you mean "minecraft:oak_planks"?`,
+		"stone":                 `did you mean "minecraft:stone"?`,
+		"minecraft:xyzzy_thing": "",
+	} {
+		err := validateMaterial(material)
+		if err == nil {
+			t.Fatalf("expected %q to be invalid", material)
+		}
+
+		if want == "" {
+			if strings.Contains(err.Error(), "did you mean") {
+				t.Fatalf("expected no suggestion for %q, got: %s", material, err)
+			}
+
+			continue
+		}
+
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %q to suggest %s, got: %s", material, want, err)
+		}
+	}
+}
+
+func TestMaterialCatalog(t *testing.T) {
+	for _, tag := range materialTags {
+		if len(materials.withTag(tag)) == 0 {
+			t.Fatalf("expected materials with tag %q", tag)
+		}
+	}
+
+	for id, tag := range map[string]string{
+		"minecraft:stone":               materialTagSolid,
+		"minecraft:glass":               materialTagTransparent,
+		"minecraft:sand":                materialTagGravity,
+		"minecraft:water":               materialTagLiquid,
+		"minecraft:air":                 materialTagAir,
+		"minecraft:red_concrete_powder": materialTagGravity,
+	} {
+		m, ok := materials.lookup(id)
+		if !ok {
+			t.Fatalf("expected %q in the catalog", id)
+		}
+
+		if !m.hasTag(tag) {
+			t.Fatalf("expected %q to have tag %q, got %v", id, tag, m.Tags)
+		}
+	}
+
+	if m, _ := materials.lookup("minecraft:sand"); m.hasTag(materialTagTransparent) {
+		t.Fatal("expected sand not to be transparent")
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var (
+	_ function.Function = OffsetFunction{}
+)
+
+func NewOffsetFunction() function.Function {
+	return OffsetFunction{}
+}
+
+type OffsetFunction struct{}
+
+func (r OffsetFunction) Metadata(_ context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "offset"
+}
+
+func (r OffsetFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Offset a position",
+		MarkdownDescription: "Returns the position moved by the given number of blocks along each axis",
+		Parameters: []function.Parameter{
+			function.ObjectParameter{
+				Name:                "pos",
+				MarkdownDescription: "Position with `x`, `y` and `z` attributes",
+				AttributeTypes:      positionAttrTypes,
+			},
+			function.Int64Parameter{
+				Name:                "dx",
+				MarkdownDescription: "Blocks to move along the X axis",
+			},
+			function.Int64Parameter{
+				Name:                "dy",
+				MarkdownDescription: "Blocks to move along the Y axis",
+			},
+			function.Int64Parameter{
+				Name:                "dz",
+				MarkdownDescription: "Blocks to move along the Z axis",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: positionAttrTypes,
+		},
+	}
+}
+
+func (r OffsetFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var pos positionModel
+	var dx, dy, dz int64
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &pos, &dx, &dy, &dz))
+
+	if resp.Error != nil {
+		return
+	}
+
+	result := positionModel{pos.X + dx, pos.Y + dy, pos.Z + dz}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, result))
+}
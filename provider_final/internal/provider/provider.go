@@ -5,13 +5,21 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"net/url"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -29,8 +37,35 @@ type MinecraftProvider struct {
 
 // MinecraftProviderModel describes the provider data model.
 type MinecraftProviderModel struct {
-	Endpoint types.String `tfsdk:"endpoint"`
-	APIKey   types.String `tfsdk:"api_key"`
+	Endpoint           types.String  `tfsdk:"endpoint"`
+	APIKey             types.String  `tfsdk:"api_key"`
+	RequestTimeout     types.String  `tfsdk:"request_timeout"`
+	MaxRetries         types.Int64   `tfsdk:"max_retries"`
+	RetryWaitMin       types.String  `tfsdk:"retry_wait_min"`
+	RetryWaitMax       types.String  `tfsdk:"retry_wait_max"`
+	RequestsPerSecond  types.Float64 `tfsdk:"requests_per_second"`
+	RequestBurst       types.Int64   `tfsdk:"request_burst"`
+	MaxConcurrent      types.Int64   `tfsdk:"max_concurrent_requests"`
+	InsecureSkipVerify types.Bool    `tfsdk:"insecure_skip_verify"`
+	CACertPEM          types.String  `tfsdk:"ca_cert_pem"`
+	CACertFile         types.String  `tfsdk:"ca_cert_file"`
+	ClientCertPEM      types.String  `tfsdk:"client_cert_pem"`
+	ClientCertFile     types.String  `tfsdk:"client_cert_file"`
+	ClientKeyPEM       types.String  `tfsdk:"client_key_pem"`
+	ClientKeyFile      types.String  `tfsdk:"client_key_file"`
+	TLSMinVersion      types.String  `tfsdk:"tls_min_version"`
+	UserAgentSuffix    types.String  `tfsdk:"user_agent_suffix"`
+	SchemaOverlap      types.String  `tfsdk:"schema_overlap"`
+	World              types.String  `tfsdk:"world"`
+	Auth               *AuthModel    `tfsdk:"auth"`
+}
+
+// AuthModel describes the provider auth block.
+type AuthModel struct {
+	Type         types.String `tfsdk:"type"`
+	Token        types.String `tfsdk:"token"`
+	TokenFile    types.String `tfsdk:"token_file"`
+	TokenCommand types.List   `tfsdk:"token_command"`
 }
 
 func (p *MinecraftProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -38,17 +73,152 @@ func (p *MinecraftProvider) Metadata(ctx context.Context, req provider.MetadataR
 	resp.Version = p.version
 }
 
+// Environment variables used for provider attributes that are not set in the
+// provider configuration.
+const (
+	envEndpoint           = "MINECRAFT_ENDPOINT"
+	envAPIKey             = "MINECRAFT_APIKEY"
+	envRequestTimeout     = "MINECRAFT_REQUEST_TIMEOUT"
+	envMaxRetries         = "MINECRAFT_MAX_RETRIES"
+	envRetryWaitMin       = "MINECRAFT_RETRY_WAIT_MIN"
+	envRetryWaitMax       = "MINECRAFT_RETRY_WAIT_MAX"
+	envRequestsPerSecond  = "MINECRAFT_REQUESTS_PER_SECOND"
+	envRequestBurst       = "MINECRAFT_REQUEST_BURST"
+	envMaxConcurrent      = "MINECRAFT_MAX_CONCURRENT_REQUESTS"
+	envInsecureSkipVerify = "MINECRAFT_INSECURE_SKIP_VERIFY"
+	envCACertPEM          = "MINECRAFT_CA_CERT_PEM"
+	envCACertFile         = "MINECRAFT_CA_CERT_FILE"
+	envClientCertPEM      = "MINECRAFT_CLIENT_CERT_PEM"
+	envClientCertFile     = "MINECRAFT_CLIENT_CERT_FILE"
+	envClientKeyPEM       = "MINECRAFT_CLIENT_KEY_PEM"
+	envClientKeyFile      = "MINECRAFT_CLIENT_KEY_FILE"
+	envTLSMinVersion      = "MINECRAFT_TLS_MIN_VERSION"
+	envUserAgentSuffix    = "MINECRAFT_USER_AGENT_SUFFIX"
+	envSchemaOverlap      = "MINECRAFT_SCHEMA_OVERLAP"
+	envWorld              = "MINECRAFT_WORLD"
+)
+
 func (p *MinecraftProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages blocks and schemas on a Minecraft server through its HTTP API.\n\n" +
+			"Every attribute outside the `auth` block can also be set with the `MINECRAFT_*` environment variable named in its description. " +
+			"An environment variable that is set takes precedence over the value in the provider configuration, " +
+			"which takes precedence over the default.",
 		Attributes: map[string]schema.Attribute{
 			"endpoint": schema.StringAttribute{
-				MarkdownDescription: "Example provider attribute",
+				MarkdownDescription: "URL of the Minecraft API, e.g. `http://localhost:9090`. Must use the `http` or `https` scheme. Can be set with `" + envEndpoint + "`, required.",
 				Optional:            true,
+				Validators: []validator.String{
+					endpointValidator{},
+				},
 			},
 			"api_key": schema.StringAttribute{
-				MarkdownDescription: "Example provider attribute",
+				MarkdownDescription: "API key sent in the `X-API-Key` header of every request to the Minecraft API. Can be set with `" + envAPIKey + "`, required unless the `auth` block is set.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"request_timeout": schema.StringAttribute{
+				MarkdownDescription: "Timeout for a single API request as a duration, e.g. `30s`. Can be set with `" + envRequestTimeout + "`. Defaults to `30s`.",
+				Optional:            true,
+			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: "Number of times idempotent requests are retried on connection errors or 5xx responses, and any request is retried on 429 responses. Can be set with `" + envMaxRetries + "`. Defaults to `4`.",
+				Optional:            true,
+			},
+			"retry_wait_min": schema.StringAttribute{
+				MarkdownDescription: "Minimum time to wait between retries as a duration, e.g. `500ms`. Can be set with `" + envRetryWaitMin + "`. Defaults to `500ms`.",
+				Optional:            true,
+			},
+			"retry_wait_max": schema.StringAttribute{
+				MarkdownDescription: "Maximum time to wait between retries as a duration, e.g. `10s`. Can be set with `" + envRetryWaitMax + "`. Defaults to `10s`.",
+				Optional:            true,
+			},
+			"requests_per_second": schema.Float64Attribute{
+				MarkdownDescription: "Maximum rate of requests sent to the Minecraft API, shared by every resource and data source, e.g. `20` or `0.5`. Lowers the load on the server tick during large applies. Can be set with `" + envRequestsPerSecond + "`. Defaults to `0`, no limit.",
+				Optional:            true,
+			},
+			"request_burst": schema.Int64Attribute{
+				MarkdownDescription: "Number of requests that can be sent at once before `requests_per_second` applies. Can be set with `" + envRequestBurst + "`. Defaults to `requests_per_second` rounded up.",
+				Optional:            true,
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of requests in flight to the Minecraft API, shared by every resource and data source. Can be set with `" + envMaxConcurrent + "`. Defaults to `0`, no limit.",
+				Optional:            true,
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				MarkdownDescription: "Skip verification of the server certificate when the endpoint uses `https`, only use this for testing. Can be set with `" + envInsecureSkipVerify + "`. Defaults to `false`.",
+				Optional:            true,
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded CA certificates used to verify the server certificate instead of the system roots. Conflicts with `ca_cert_file`. Can be set with `" + envCACertPEM + "`.",
 				Optional:            true,
 			},
+			"ca_cert_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a file containing the PEM encoded CA certificates used to verify the server certificate. Conflicts with `ca_cert_pem`. Can be set with `" + envCACertFile + "`.",
+				Optional:            true,
+			},
+			"client_cert_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded client certificate presented to servers that require mutual TLS, requires a client key. Conflicts with `client_cert_file`. Can be set with `" + envClientCertPEM + "`.",
+				Optional:            true,
+			},
+			"client_cert_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a file containing the PEM encoded client certificate. Conflicts with `client_cert_pem`. Can be set with `" + envClientCertFile + "`.",
+				Optional:            true,
+			},
+			"client_key_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded private key of the client certificate. Conflicts with `client_key_file`. Can be set with `" + envClientKeyPEM + "`.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"client_key_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a file containing the PEM encoded private key of the client certificate. Conflicts with `client_key_pem`. Can be set with `" + envClientKeyFile + "`.",
+				Optional:            true,
+			},
+			"tls_min_version": schema.StringAttribute{
+				MarkdownDescription: "Minimum TLS version used to connect to `https` endpoints. One of `1.0`, `1.1`, `1.2` or `1.3`. Can be set with `" + envTLSMinVersion + "`. Defaults to `" + defaultTLSMinVersion + "`.",
+				Optional:            true,
+			},
+			"user_agent_suffix": schema.StringAttribute{
+				MarkdownDescription: "Text appended to the `User-Agent` header of every request, e.g. to identify the pipeline running Terraform. Can be set with `" + envUserAgentSuffix + "`.",
+				Optional:            true,
+			},
+			"schema_overlap": schema.StringAttribute{
+				MarkdownDescription: "How planned `minecraft_schema` placements that intersect each other, or schemas already placed on the server, are reported. One of `error`, `warning` or `ignore`. Can be set with `" + envSchemaOverlap + "`. Defaults to `error`.",
+				Optional:            true,
+			},
+			"world": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("World used by resources and data sources that do not set `world`, e.g. `minecraft:the_nether` or `minecraft:the_end`. Can be set with `%s`. Defaults to `%s`. Other worlds are selected with the `world` query parameter and require a server that returns the world of each response in the `%s` header, requests to them fail on servers that do not.", envWorld, defaultWorld, worldHeader),
+				Optional:            true,
+				Validators: []validator.String{
+					worldValidator{},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"auth": schema.SingleNestedBlock{
+				MarkdownDescription: "Authentication used instead of `api_key`. Exactly one of `token`, `token_file` or `token_command` must be set. " +
+					"A token read from a file or command is read again when the server rejects it, so credentials rotated during an apply are used.",
+				Attributes: map[string]schema.Attribute{
+					"type": schema.StringAttribute{
+						MarkdownDescription: "How the token is sent, `api_key` uses the `X-API-Key` header and `bearer` the `Authorization: Bearer` header. Defaults to `api_key`.",
+						Optional:            true,
+					},
+					"token": schema.StringAttribute{
+						MarkdownDescription: "Static token.",
+						Optional:            true,
+						Sensitive:           true,
+					},
+					"token_file": schema.StringAttribute{
+						MarkdownDescription: "Path to a file containing the token, surrounding whitespace is ignored.",
+						Optional:            true,
+					},
+					"token_command": schema.ListAttribute{
+						MarkdownDescription: "Command and arguments that print the token, e.g. `[\"vault\", \"read\", \"-field=token\", \"secret/minecraft\"]`.",
+						ElementType:         types.StringType,
+						Optional:            true,
+					},
+				},
+			},
 		},
 	}
 }
@@ -62,64 +232,406 @@ func (p *MinecraftProvider) Configure(ctx context.Context, req provider.Configur
 		return
 	}
 
-	endpoint := ""
-	apiKey := ""
+	client := newProviderClient(data, p.version, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.DataSourceData = client
+	resp.ResourceData = client
+}
 
-	// set endpoint and apiKey from config
-	if !data.Endpoint.IsNull() {
-		endpoint = data.Endpoint.ValueString()
+// newProviderClient creates the client shared by the resources and data
+// sources. Environment variables that are set override the configured
+// attributes, attributes set in neither use the default.
+func newProviderClient(data MinecraftProviderModel, version string, diags *diag.Diagnostics) *client {
+	endpoint := stringSetting(data.Endpoint, envEndpoint)
+	apiKey := stringSetting(data.APIKey, envAPIKey)
+
+	if endpoint == "" {
+		diags.AddAttributeError(
+			path.Root("endpoint"),
+			"Configuration Error",
+			"Unable to set endpoint, please set either the endpoint property in the provider or the environment variable '"+envEndpoint+"'",
+		)
+	} else if err := validateEndpoint(endpoint); err != nil {
+		diags.AddAttributeError(path.Root("endpoint"), "Invalid Endpoint", err.Error())
 	}
 
-	if !data.APIKey.IsNull() {
-		apiKey = data.APIKey.ValueString()
+	var auth *authenticator
+	switch {
+	case data.Auth != nil:
+		if !data.APIKey.IsNull() {
+			diags.AddAttributeError(
+				path.Root("api_key"),
+				"Conflicting Attributes",
+				"The api_key attribute can not be used with the auth block, set auth.token instead",
+			)
+		}
+
+		auth = newProviderAuth(data.Auth, diags)
+	case apiKey == "":
+		diags.AddAttributeError(
+			path.Root("api_key"),
+			"Configuration Error",
+			"Unable to set api_key, please set either the api_key property or the auth block in the provider, or the environment variable '"+envAPIKey+"'",
+		)
 	}
 
-	// override from environment variables if set
-	if ep := os.Getenv("MINECRAFT_ENDPOINT"); ep != "" {
-		endpoint = ep
+	opts := clientOptions{
+		Timeout:      durationSetting(data.RequestTimeout, path.Root("request_timeout"), envRequestTimeout, diags),
+		MaxRetries:   int(int64Setting(data.MaxRetries, path.Root("max_retries"), envMaxRetries, defaultMaxRetries, diags)),
+		RetryWaitMin: durationSetting(data.RetryWaitMin, path.Root("retry_wait_min"), envRetryWaitMin, diags),
+		RetryWaitMax: durationSetting(data.RetryWaitMax, path.Root("retry_wait_max"), envRetryWaitMax, diags),
+		UserAgent:    userAgent(version, stringSetting(data.UserAgentSuffix, envUserAgentSuffix)),
+		Auth:         auth,
+
+		RequestsPerSecond:     float64Setting(data.RequestsPerSecond, path.Root("requests_per_second"), envRequestsPerSecond, diags),
+		RequestBurst:          int(int64Setting(data.RequestBurst, path.Root("request_burst"), envRequestBurst, 0, diags)),
+		MaxConcurrentRequests: int(int64Setting(data.MaxConcurrent, path.Root("max_concurrent_requests"), envMaxConcurrent, 0, diags)),
 	}
 
-	if apk := os.Getenv("MINECRAFT_APIKEY"); apk != "" {
-		apiKey = apk
+	if opts.MaxRetries < 0 {
+		diags.AddAttributeError(
+			path.Root("max_retries"),
+			"Invalid Max Retries",
+			fmt.Sprintf("max_retries must be zero or more, got %d", opts.MaxRetries),
+		)
 	}
 
-	if endpoint == "" {
-		resp.Diagnostics.AddError(
-			"Configuration Error",
-			"Unable to set endpoint, please set either the endpoint property in the provider or the environment variable 'MINECRAFT_ENDPOINT'",
+	if opts.RequestsPerSecond < 0 {
+		diags.AddAttributeError(
+			path.Root("requests_per_second"),
+			"Invalid Requests Per Second",
+			fmt.Sprintf("requests_per_second must be zero or more, got %g", opts.RequestsPerSecond),
 		)
-		return
 	}
 
-	if apiKey == "" {
-		resp.Diagnostics.AddError(
-			"Configuration Error",
-			"unable to set endpoint, please set either the endpoint property in the provider or the environment variable 'MINECRAFT_APIKEY'",
+	if opts.RequestBurst < 0 {
+		diags.AddAttributeError(
+			path.Root("request_burst"),
+			"Invalid Request Burst",
+			fmt.Sprintf("request_burst must be zero or more, got %d", opts.RequestBurst),
+		)
+	}
+
+	if opts.MaxConcurrentRequests < 0 {
+		diags.AddAttributeError(
+			path.Root("max_concurrent_requests"),
+			"Invalid Max Concurrent Requests",
+			fmt.Sprintf("max_concurrent_requests must be zero or more, got %d", opts.MaxConcurrentRequests),
+		)
+	}
+
+	clientKey := pemSetting(data.ClientKeyPEM, data.ClientKeyFile, "client_key", envClientKeyPEM, envClientKeyFile, diags)
+
+	tlsConfig, err := newTLSConfig(tlsOptions{
+		InsecureSkipVerify: boolSetting(data.InsecureSkipVerify, path.Root("insecure_skip_verify"), envInsecureSkipVerify, diags),
+		CACertPEM:          pemSetting(data.CACertPEM, data.CACertFile, "ca_cert", envCACertPEM, envCACertFile, diags),
+		ClientCertPEM:      pemSetting(data.ClientCertPEM, data.ClientCertFile, "client_cert", envClientCertPEM, envClientCertFile, diags),
+		ClientKeyPEM:       clientKey,
+		MinVersion:         stringSetting(data.TLSMinVersion, envTLSMinVersion),
+	})
+	if err != nil {
+		diags.AddError("Invalid TLS Configuration", err.Error())
+	}
+
+	opts.TLSConfig = tlsConfig
+	opts.Secrets = []string{clientKey}
+
+	if data.Auth != nil {
+		opts.Secrets = append(opts.Secrets, data.Auth.Token.ValueString())
+	}
+
+	overlap := stringSetting(data.SchemaOverlap, envSchemaOverlap)
+	if overlap == "" {
+		overlap = schemaOverlapError
+	}
+
+	switch overlap {
+	case schemaOverlapError, schemaOverlapWarning, schemaOverlapIgnore:
+	default:
+		diags.AddAttributeError(
+			path.Root("schema_overlap"),
+			"Invalid Schema Overlap",
+			fmt.Sprintf("Unknown value %q, must be one of error, warning or ignore", overlap),
+		)
+	}
+
+	world := stringSetting(data.World, envWorld)
+	if world == "" {
+		world = defaultWorld
+	}
+
+	if err := validateWorld(world); err != nil {
+		diags.AddAttributeError(path.Root("world"), "Invalid World", err.Error())
+	}
+
+	if diags.HasError() {
+		return nil
+	}
+
+	client := newClient(strings.TrimSuffix(endpoint, "/"), apiKey, opts)
+	client.placements.mode = overlap
+	client.world = world
+
+	return client
+}
+
+// newProviderAuth returns the authenticator for the provider auth block.
+func newProviderAuth(m *AuthModel, diags *diag.Diagnostics) *authenticator {
+	authPath := path.Root("auth")
+
+	authType := m.Type.ValueString()
+	switch authType {
+	case "":
+		authType = authTypeAPIKey
+	case authTypeAPIKey, authTypeBearer:
+	default:
+		diags.AddAttributeError(
+			authPath.AtName("type"),
+			"Invalid Auth Type",
+			fmt.Sprintf("Unknown value %q, must be one of api_key or bearer", authType),
+		)
+
+		return nil
+	}
+
+	command := []string{}
+	if !m.TokenCommand.IsNull() && !m.TokenCommand.IsUnknown() {
+		diags.Append(m.TokenCommand.ElementsAs(context.Background(), &command, false)...)
+	}
+
+	sources := 0
+	for _, set := range []bool{m.Token.ValueString() != "", m.TokenFile.ValueString() != "", len(command) > 0} {
+		if set {
+			sources++
+		}
+	}
+
+	if sources != 1 {
+		diags.AddAttributeError(
+			authPath,
+			"Invalid Auth Block",
+			"Exactly one of token, token_file or token_command must be set",
 		)
+
+		return nil
+	}
+
+	switch {
+	case m.TokenFile.ValueString() != "":
+		return newSourceAuth(authType, fileTokenSource(m.TokenFile.ValueString()))
+	case len(command) > 0:
+		return newSourceAuth(authType, commandTokenSource(command))
+	default:
+		return newStaticAuth(authType, m.Token.ValueString())
+	}
+}
+
+// userAgent returns the User-Agent header sent by the provider.
+func userAgent(version, suffix string) string {
+	ua := "terraform-provider-minecraft/" + version
+	if suffix != "" {
+		ua += " " + suffix
+	}
+
+	return ua
+}
+
+// validateEndpoint returns an error when endpoint is not an absolute http or
+// https URL.
+func validateEndpoint(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid endpoint %q, must be an http or https URL such as http://localhost:9090", endpoint)
+	}
+
+	return nil
+}
+
+// endpointValidator validates the provider endpoint attribute.
+type endpointValidator struct{}
+
+func (v endpointValidator) Description(ctx context.Context) string {
+	return "endpoint must be an http or https URL"
+}
+
+func (v endpointValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v endpointValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
 		return
 	}
 
-	// Example client configuration for data sources and resources
-	client := newClient(endpoint, apiKey)
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	if err := validateEndpoint(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Endpoint", err.Error())
+	}
+}
+
+// stringSetting returns the environment variable env, or the configured
+// value of v when env is not set.
+func stringSetting(v types.String, env string) string {
+	if s := os.Getenv(env); s != "" {
+		return s
+	}
+
+	return v.ValueString()
+}
+
+// pemSetting returns the PEM content of a setting that can be given inline or
+// as a file, the attributes are named <prefix>_pem and <prefix>_file. Either
+// environment variable takes precedence over both configured attributes.
+func pemSetting(content, file types.String, prefix, contentEnv, fileEnv string, diags *diag.Diagnostics) string {
+	pemPath, filePath := path.Root(prefix+"_pem"), path.Root(prefix+"_file")
+
+	var pem, filename string
+	pem, filename = os.Getenv(contentEnv), os.Getenv(fileEnv)
+	if pem == "" && filename == "" {
+		pem, filename = content.ValueString(), file.ValueString()
+	}
+
+	if pem != "" && filename != "" {
+		diags.AddAttributeError(
+			filePath,
+			"Conflicting Attributes",
+			fmt.Sprintf("Only one of %s and %s can be set", pemPath, filePath),
+		)
+
+		return ""
+	}
+
+	if filename == "" {
+		return pem
+	}
+
+	d, err := os.ReadFile(filename)
+	if err != nil {
+		diags.AddAttributeError(filePath, "Unable to Read File", fmt.Sprintf("Unable to read %s: %s", filePath, err))
+	}
+
+	return string(d)
+}
+
+// boolSetting returns the environment variable env, or the configured value
+// of v when env is not set. It is false when neither is set.
+func boolSetting(v types.Bool, p path.Path, env string, diags *diag.Diagnostics) bool {
+	s := os.Getenv(env)
+	if s == "" {
+		return v.ValueBool()
+	}
+
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		diags.AddAttributeError(
+			p,
+			"Invalid Environment Variable",
+			fmt.Sprintf("Unable to parse %s=%q as a boolean, e.g. true or false", env, s),
+		)
+	}
+
+	return b
+}
+
+// int64Setting returns the environment variable env, or the configured value
+// of v when env is not set. It is def when neither is set.
+func int64Setting(v types.Int64, p path.Path, env string, def int64, diags *diag.Diagnostics) int64 {
+	s := os.Getenv(env)
+	if s == "" {
+		if v.IsNull() || v.IsUnknown() {
+			return def
+		}
+
+		return v.ValueInt64()
+	}
+
+	i, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		diags.AddAttributeError(
+			p,
+			"Invalid Environment Variable",
+			fmt.Sprintf("Unable to parse %s=%q as a whole number", env, s),
+		)
+	}
+
+	return i
+}
+
+// float64Setting returns the environment variable env, or the configured
+// value of v when env is not set. It is zero when neither is set.
+func float64Setting(v types.Float64, p path.Path, env string, diags *diag.Diagnostics) float64 {
+	s := os.Getenv(env)
+	if s == "" {
+		return v.ValueFloat64()
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		diags.AddAttributeError(
+			p,
+			"Invalid Environment Variable",
+			fmt.Sprintf("Unable to parse %s=%q as a number", env, s),
+		)
+	}
+
+	return f
+}
+
+// durationSetting parses the duration in the environment variable env, or the
+// configured duration in v when env is not set. It returns zero when neither is set so that
+// the client default is used.
+func durationSetting(v types.String, p path.Path, env string, diags *diag.Diagnostics) time.Duration {
+	s := stringSetting(v, env)
+	if s == "" {
+		return 0
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		source := "the provider configuration"
+		if os.Getenv(env) != "" {
+			source = "the environment variable " + env
+		}
+
+		diags.AddAttributeError(
+			p,
+			"Invalid Duration",
+			fmt.Sprintf("Unable to parse %q from %s as a duration, e.g. 30s: %s", s, source, err),
+		)
+	}
+
+	return d
 }
 
 func (p *MinecraftProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewSchemaResource,
+		NewBlockResource,
+		NewFillResource,
+		NewShapeResource,
 	}
 }
 
 func (p *MinecraftProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewBlockDataSource,
+		NewRegionDataSource,
+		NewSchemaDataSource,
+		NewSchemasDataSource,
+		NewMaterialsDataSource,
 	}
 }
 
 func (p *MinecraftProvider) Functions(ctx context.Context) []func() function.Function {
 	return []func() function.Function{
-		NewExampleFunction,
+		NewOffsetFunction,
+		NewRotateFunction,
+		NewBoundingBoxFunction,
+		NewDistanceFunction,
+		NewChunkOfFunction,
+		NewSchemaInfoFunction,
 	}
 }
 
@@ -1,9 +1,18 @@
 package provider
 
 import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 )
 
@@ -15,8 +24,343 @@ var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServe
 	"minecraft": providerserver.NewProtocol6WithError(New("test")()),
 }
 
+// testAccServer is the fake Minecraft API used by the current acceptance test,
+// it is nil when the tests run against a live server.
+var testAccServer *fakeMinecraftServer
+
 func testAccPreCheck(t *testing.T) {
-	// You can add code here to run prior to any test case execution, for example assertions
-	// about the appropriate environment variables being set are common to see in a pre-check
-	// function.
+	// Run against a live server when one is configured, otherwise start an
+	// in-process fake so the tests can run offline.
+	if os.Getenv(envEndpoint) != "" {
+		testAccServer = nil
+		return
+	}
+
+	testAccServer = newFakeMinecraftServer(t)
+
+	t.Setenv(envEndpoint, testAccServer.URL)
+	t.Setenv(envAPIKey, fakeAPIKey)
+}
+
+// clearProviderEnv unsets the provider environment variables for the duration
+// of the test.
+func clearProviderEnv(t *testing.T) {
+	t.Helper()
+
+	for _, env := range []string{
+		envEndpoint, envAPIKey, envRequestTimeout, envMaxRetries, envRetryWaitMin, envRetryWaitMax,
+		envRequestsPerSecond, envRequestBurst, envMaxConcurrent,
+		envInsecureSkipVerify, envCACertPEM, envCACertFile, envClientCertPEM, envClientCertFile,
+		envClientKeyPEM, envClientKeyFile, envTLSMinVersion, envUserAgentSuffix, envSchemaOverlap, envWorld,
+	} {
+		t.Setenv(env, "")
+	}
+}
+
+func TestProviderConfigPrecedence(t *testing.T) {
+	clearProviderEnv(t)
+
+	configured := MinecraftProviderModel{
+		Endpoint:           types.StringValue("https://config.example.com/"),
+		APIKey:             types.StringValue("config-key"),
+		RequestTimeout:     types.StringValue("1m"),
+		MaxRetries:         types.Int64Value(0),
+		RequestsPerSecond:  types.Float64Value(10),
+		RequestBurst:       types.Int64Value(20),
+		MaxConcurrent:      types.Int64Value(1),
+		InsecureSkipVerify: types.BoolValue(false),
+		UserAgentSuffix:    types.StringValue("config-suffix"),
+		SchemaOverlap:      types.StringValue(schemaOverlapIgnore),
+		World:              types.StringValue("minecraft:the_nether"),
+	}
+
+	// the configuration is used when the environment is not set
+	var diags diag.Diagnostics
+	c := newProviderClient(configured, "test", &diags)
+	if diags.HasError() {
+		t.Fatalf("expected no errors, got: %v", diags)
+	}
+
+	if c.baseURL != "https://config.example.com" || c.auth.token != "config-key" {
+		t.Fatalf("expected the endpoint and key from the configuration, got %s and %s", c.baseURL, c.auth.token)
+	}
+
+	if c.httpClient.Timeout != time.Minute || c.maxRetries != 0 {
+		t.Fatalf("expected the timeout and retries from the configuration, got %s and %d", c.httpClient.Timeout, c.maxRetries)
+	}
+
+	if c.limiter.rate != 10 || c.limiter.burst != 20 || cap(c.limiter.slots) != 1 {
+		t.Fatalf("expected the rate limits from the configuration, got %g, %g and %d", c.limiter.rate, c.limiter.burst, cap(c.limiter.slots))
+	}
+
+	if c.httpClient.Transport.(*http.Transport).TLSClientConfig.InsecureSkipVerify {
+		t.Fatal("expected insecure_skip_verify from the configuration")
+	}
+
+	if c.userAgent != "terraform-provider-minecraft/test config-suffix" {
+		t.Fatalf("unexpected user agent: %s", c.userAgent)
+	}
+
+	if c.placements.mode != schemaOverlapIgnore || c.world != "minecraft:the_nether" {
+		t.Fatalf("expected schema_overlap and world from the configuration, got %s and %s", c.placements.mode, c.world)
+	}
+
+	t.Setenv(envEndpoint, "http://env.example.com:9090")
+	t.Setenv(envAPIKey, "env-key")
+	t.Setenv(envRequestTimeout, "5s")
+	t.Setenv(envMaxRetries, "2")
+	t.Setenv(envRequestsPerSecond, "2.5")
+	t.Setenv(envRequestBurst, "3")
+	t.Setenv(envMaxConcurrent, "3")
+	t.Setenv(envInsecureSkipVerify, "true")
+	t.Setenv(envUserAgentSuffix, "env-suffix")
+	t.Setenv(envSchemaOverlap, schemaOverlapWarning)
+	t.Setenv(envWorld, "minecraft:the_end")
+
+	// the environment takes precedence over the configuration, and is used
+	// for attributes that are not configured
+	for name, model := range map[string]MinecraftProviderModel{"configured": configured, "unconfigured": {}} {
+		t.Run(name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			c := newProviderClient(model, "test", &diags)
+			if diags.HasError() {
+				t.Fatalf("expected no errors, got: %v", diags)
+			}
+
+			if c.baseURL != "http://env.example.com:9090" || c.auth.token != "env-key" {
+				t.Fatalf("expected the endpoint and key from the environment, got %s and %s", c.baseURL, c.auth.token)
+			}
+
+			if c.httpClient.Timeout != 5*time.Second || c.maxRetries != 2 {
+				t.Fatalf("expected the timeout and retries from the environment, got %s and %d", c.httpClient.Timeout, c.maxRetries)
+			}
+
+			if c.limiter.rate != 2.5 || c.limiter.burst != 3 || cap(c.limiter.slots) != 3 {
+				t.Fatalf("expected the rate limits from the environment, got %g, %g and %d", c.limiter.rate, c.limiter.burst, cap(c.limiter.slots))
+			}
+
+			if !c.httpClient.Transport.(*http.Transport).TLSClientConfig.InsecureSkipVerify {
+				t.Fatal("expected insecure_skip_verify from the environment")
+			}
+
+			if c.userAgent != "terraform-provider-minecraft/test env-suffix" {
+				t.Fatalf("unexpected user agent: %s", c.userAgent)
+			}
+
+			if c.placements.mode != schemaOverlapWarning || c.world != "minecraft:the_end" {
+				t.Fatalf("expected schema_overlap and world from the environment, got %s and %s", c.placements.mode, c.world)
+			}
+		})
+	}
+}
+
+func TestProviderConfigDefaults(t *testing.T) {
+	clearProviderEnv(t)
+
+	var diags diag.Diagnostics
+	c := newProviderClient(MinecraftProviderModel{
+		Endpoint: types.StringValue("http://localhost:9090"),
+		APIKey:   types.StringValue("key"),
+	}, "dev", &diags)
+	if diags.HasError() {
+		t.Fatalf("expected no errors, got: %v", diags)
+	}
+
+	if c.httpClient.Timeout != defaultTimeout || c.maxRetries != defaultMaxRetries {
+		t.Fatalf("expected the default timeout and retries, got %s and %d", c.httpClient.Timeout, c.maxRetries)
+	}
+
+	if c.limiter.rate != 0 || c.limiter.slots != nil {
+		t.Fatal("expected requests to not be limited by default")
+	}
+
+	if c.userAgent != "terraform-provider-minecraft/dev" {
+		t.Fatalf("unexpected user agent: %s", c.userAgent)
+	}
+
+	if c.placements.mode != schemaOverlapError || c.world != defaultWorld {
+		t.Fatalf("expected the default schema_overlap and world, got %s and %s", c.placements.mode, c.world)
+	}
+}
+
+func TestProviderConfigErrors(t *testing.T) {
+	valid := MinecraftProviderModel{
+		Endpoint: types.StringValue("http://localhost:9090"),
+		APIKey:   types.StringValue("key"),
+	}
+
+	tests := []struct {
+		name  string
+		env   map[string]string
+		model func(m *MinecraftProviderModel)
+		want  string
+	}{
+		{
+			name:  "missing endpoint",
+			model: func(m *MinecraftProviderModel) { m.Endpoint = types.StringNull() },
+			want:  envEndpoint,
+		},
+		{
+			name:  "missing api key",
+			model: func(m *MinecraftProviderModel) { m.APIKey = types.StringNull() },
+			want:  "Unable to set api_key",
+		},
+		{
+			name:  "invalid endpoint",
+			model: func(m *MinecraftProviderModel) { m.Endpoint = types.StringValue("localhost:9090") },
+			want:  "must be an http or https URL",
+		},
+		{
+			name:  "invalid endpoint in environment",
+			env:   map[string]string{envEndpoint: "ftp://localhost"},
+			model: func(m *MinecraftProviderModel) { m.Endpoint = types.StringNull() },
+			want:  "must be an http or https URL",
+		},
+		{
+			name: "invalid max retries in environment",
+			env:  map[string]string{envMaxRetries: "many"},
+			want: envMaxRetries,
+		},
+		{
+			name:  "negative max retries",
+			model: func(m *MinecraftProviderModel) { m.MaxRetries = types.Int64Value(-1) },
+			want:  "zero or more",
+		},
+		{
+			name:  "negative requests per second",
+			model: func(m *MinecraftProviderModel) { m.RequestsPerSecond = types.Float64Value(-1) },
+			want:  "zero or more",
+		},
+		{
+			name: "invalid requests per second in environment",
+			env:  map[string]string{envRequestsPerSecond: "fast"},
+			want: envRequestsPerSecond,
+		},
+		{
+			name:  "negative max concurrent requests",
+			model: func(m *MinecraftProviderModel) { m.MaxConcurrent = types.Int64Value(-2) },
+			want:  "zero or more",
+		},
+		{
+			name: "invalid duration in environment",
+			env:  map[string]string{envRequestTimeout: "soon"},
+			want: envRequestTimeout,
+		},
+		{
+			name: "invalid boolean in environment",
+			env:  map[string]string{envInsecureSkipVerify: "maybe"},
+			want: envInsecureSkipVerify,
+		},
+		{
+			name:  "invalid ca certificate",
+			model: func(m *MinecraftProviderModel) { m.CACertPEM = types.StringValue("not a certificate") },
+			want:  "PEM encoded certificates",
+		},
+		{
+			name: "api key with auth block",
+			model: func(m *MinecraftProviderModel) {
+				m.Auth = &AuthModel{Token: types.StringValue("token")}
+			},
+			want: "can not be used with the auth block",
+		},
+		{
+			name: "auth block without token",
+			model: func(m *MinecraftProviderModel) {
+				m.APIKey = types.StringNull()
+				m.Auth = &AuthModel{}
+			},
+			want: "Exactly one of token, token_file or token_command",
+		},
+		{
+			name: "auth block with two tokens",
+			model: func(m *MinecraftProviderModel) {
+				m.APIKey = types.StringNull()
+				m.Auth = &AuthModel{Token: types.StringValue("token"), TokenFile: types.StringValue("token.txt")}
+			},
+			want: "Exactly one of token, token_file or token_command",
+		},
+		{
+			name: "invalid auth type",
+			model: func(m *MinecraftProviderModel) {
+				m.APIKey = types.StringNull()
+				m.Auth = &AuthModel{Type: types.StringValue("basic"), Token: types.StringValue("token")}
+			},
+			want: "must be one of api_key or bearer",
+		},
+		{
+			name: "invalid world in environment",
+			env:  map[string]string{envWorld: "nether"},
+			want: "invalid world",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearProviderEnv(t)
+
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			m := valid
+			if tt.model != nil {
+				tt.model(&m)
+			}
+
+			var diags diag.Diagnostics
+			if c := newProviderClient(m, "test", &diags); c != nil {
+				t.Fatal("expected no client")
+			}
+
+			if !diags.HasError() || !strings.Contains(diags.Errors()[0].Detail(), tt.want) {
+				t.Fatalf("expected an error containing %q, got: %v", tt.want, diags)
+			}
+		})
+	}
+}
+
+func TestProviderConfigCACertificate(t *testing.T) {
+	clearProviderEnv(t)
+
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ua := r.Header.Get("User-Agent"); ua != "terraform-provider-minecraft/test" {
+			http.Error(w, "unexpected user agent "+ua, http.StatusBadRequest)
+			return
+		}
+
+		w.Write([]byte(`{"id":"1","x":0,"y":0,"z":0,"material":"minecraft:stone"}`))
+	}))
+	defer srv.Close()
+
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+
+	// the test server certificate is not trusted by the system roots
+	var diags diag.Diagnostics
+	c := newProviderClient(MinecraftProviderModel{
+		Endpoint:   types.StringValue(srv.URL),
+		APIKey:     types.StringValue("key"),
+		MaxRetries: types.Int64Value(0),
+	}, "test", &diags)
+	if diags.HasError() {
+		t.Fatalf("expected no errors, got: %v", diags)
+	}
+
+	if _, err := c.getBlock(context.Background(), defaultWorld, 0, 0, 0); err == nil {
+		t.Fatal("expected the untrusted certificate to be rejected")
+	}
+
+	t.Setenv(envCACertPEM, string(ca))
+
+	c = newProviderClient(MinecraftProviderModel{
+		Endpoint:   types.StringValue(srv.URL),
+		APIKey:     types.StringValue("key"),
+		MaxRetries: types.Int64Value(0),
+	}, "test", &diags)
+	if diags.HasError() {
+		t.Fatalf("expected no errors, got: %v", diags)
+	}
+
+	if _, err := c.getBlock(context.Background(), defaultWorld, 0, 0, 0); err != nil {
+		t.Fatalf("expected the CA certificate to be trusted, got: %s", err)
+	}
 }
@@ -0,0 +1,162 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// rateLimiter limits the requests sent by a client with a token bucket and a
+// maximum number of requests in flight. The provider creates a single client,
+// so every resource and data source of an apply shares the same limits.
+type rateLimiter struct {
+	mu sync.Mutex
+
+	// rate is the number of tokens added per second, zero disables the token
+	// bucket.
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+
+	// pausedUntil delays every request until the time requested by the
+	// server in a Retry-After header.
+	pausedUntil time.Time
+
+	// slots holds a value for each request in flight, it is nil when the
+	// number of requests in flight is not limited.
+	slots chan struct{}
+}
+
+// newRateLimiter returns a limiter allowing rate requests per second with
+// bursts of up to burst requests, and at most maxInFlight concurrent
+// requests. A rate or maxInFlight of zero is not limited, a burst of zero
+// allows one second worth of requests.
+func newRateLimiter(rate float64, burst, maxInFlight int) *rateLimiter {
+	l := &rateLimiter{rate: rate, burst: float64(burst)}
+
+	if l.burst <= 0 {
+		l.burst = math.Max(1, math.Ceil(rate))
+	}
+
+	l.tokens = l.burst
+
+	if maxInFlight > 0 {
+		l.slots = make(chan struct{}, maxInFlight)
+	}
+
+	return l
+}
+
+// acquire blocks until a request can be sent, the returned function must be
+// called once the response has been read. It returns early with an error if
+// the context is cancelled.
+func (l *rateLimiter) acquire(ctx context.Context) (func(), error) {
+	release := func() {}
+
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+			release = func() { <-l.slots }
+		case <-ctx.Done():
+			return nil, fmt.Errorf("request cancelled while waiting for a free connection: %w", ctx.Err())
+		}
+	}
+
+	wait := l.reserve(time.Now())
+	if wait <= 0 {
+		return release, nil
+	}
+
+	t := time.NewTimer(wait)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		l.cancel()
+		release()
+		return nil, fmt.Errorf("request cancelled while waiting for the rate limit: %w", ctx.Err())
+	case <-t.C:
+		return release, nil
+	}
+}
+
+// reserve takes a token from the bucket and returns how long the request has
+// to wait for it, and for any pause requested by the server. The bucket goes
+// negative when it is empty so that waiting requests are queued in order.
+func (l *rateLimiter) reserve(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var wait time.Duration
+	if l.pausedUntil.After(now) {
+		wait = l.pausedUntil.Sub(now)
+	}
+
+	if l.rate <= 0 {
+		return wait
+	}
+
+	if !l.last.IsZero() {
+		l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	}
+
+	l.last = now
+	l.tokens--
+
+	if l.tokens < 0 {
+		if d := time.Duration(-l.tokens / l.rate * float64(time.Second)); d > wait {
+			wait = d
+		}
+	}
+
+	return wait
+}
+
+// cancel returns the token of a request that stopped waiting.
+func (l *rateLimiter) cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.rate > 0 {
+		l.tokens = math.Min(l.burst, l.tokens+1)
+	}
+}
+
+// pause delays every request for d, it never shortens an earlier pause.
+func (l *rateLimiter) pause(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if until := time.Now().Add(d); until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+}
+
+// retryAfter returns the delay requested by the Retry-After header of a
+// response, given either as a number of seconds or as an HTTP date.
+func retryAfter(h http.Header, now time.Time) (time.Duration, bool) {
+	v := h.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+
+	if s, err := strconv.Atoi(v); err == nil && s >= 0 {
+		return time.Duration(s) * time.Second, true
+	}
+
+	t, err := http.ParseTime(v)
+	if err != nil {
+		return 0, false
+	}
+
+	if d := t.Sub(now); d > 0 {
+		return d, true
+	}
+
+	return 0, true
+}
@@ -0,0 +1,199 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterRate(t *testing.T) {
+	l := newRateLimiter(10, 2, 0)
+	now := time.Now()
+
+	// the burst is available at once, further requests are spaced by the rate
+	for i, want := range []time.Duration{0, 0, 100 * time.Millisecond, 200 * time.Millisecond} {
+		if got := l.reserve(now); got != want {
+			t.Fatalf("request %d: expected to wait %s, got %s", i, want, got)
+		}
+	}
+
+	// the bucket refills over time, up to the burst
+	if got := l.reserve(now.Add(time.Second)); got != 0 {
+		t.Fatalf("expected the bucket to refill, got a wait of %s", got)
+	}
+
+	l.cancel()
+
+	if l.tokens > l.burst {
+		t.Fatalf("expected the tokens to be capped at the burst, got %g", l.tokens)
+	}
+}
+
+func TestRateLimiterUnlimited(t *testing.T) {
+	l := newRateLimiter(0, 0, 0)
+
+	for i := 0; i < 100; i++ {
+		if got := l.reserve(time.Now()); got != 0 {
+			t.Fatalf("expected no wait, got %s", got)
+		}
+	}
+
+	l.pause(time.Minute)
+
+	if got := l.reserve(time.Now()); got < 59*time.Second {
+		t.Fatalf("expected requests to wait for the pause, got %s", got)
+	}
+}
+
+func TestRateLimiterCancel(t *testing.T) {
+	l := newRateLimiter(0, 0, 1)
+
+	release, err := l.acquire(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if _, err := l.acquire(ctx); err == nil {
+		t.Fatal("expected an error while all slots are in use")
+	}
+
+	release()
+
+	if _, err := l.acquire(context.Background()); err != nil {
+		t.Fatalf("expected the released slot to be available, got: %s", err)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		{"", 0, false},
+		{"3", 3 * time.Second, true},
+		{"-1", 0, false},
+		{"soon", 0, false},
+		{now.Add(90 * time.Second).Format(http.TimeFormat), 90 * time.Second, true},
+		{now.Add(-time.Minute).Format(http.TimeFormat), 0, true},
+	}
+
+	for _, tt := range tests {
+		h := http.Header{}
+		if tt.value != "" {
+			h.Set("Retry-After", tt.value)
+		}
+
+		got, ok := retryAfter(h, now)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("retryAfter(%q): expected %s, %t, got %s, %t", tt.value, tt.want, tt.ok, got, ok)
+		}
+	}
+}
+
+func TestClientMaxConcurrentRequests(t *testing.T) {
+	var inFlight, peak int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+
+		time.Sleep(10 * time.Millisecond)
+		w.Write([]byte(`{"id":"1","material":"minecraft:stone"}`))
+	}))
+	defer srv.Close()
+
+	opts := testClientOptions()
+	opts.MaxConcurrentRequests = 2
+
+	c := newClient(srv.URL, "key", opts)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			if _, err := c.getBlock(context.Background(), defaultWorld, i, 0, 0); err != nil {
+				t.Error(err)
+			}
+		}(i)
+	}
+
+	wg.Wait()
+
+	if peak != 2 {
+		t.Fatalf("expected at most 2 requests in flight, got: %d", peak)
+	}
+}
+
+func TestClientRetriesRateLimitedPost(t *testing.T) {
+	var calls int32
+	var times []time.Time
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		times = append(times, time.Now())
+
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		w.Write([]byte(`{"id":"1","x":1,"y":2,"z":3,"material":"minecraft:stone"}`))
+	}))
+	defer srv.Close()
+
+	c := newClient(srv.URL, "key", testClientOptions())
+
+	block, err := c.createBlock(context.Background(), defaultWorld, blockRequest{X: 1, Y: 2, Z: 3, Material: "minecraft:stone"})
+	if err != nil {
+		t.Fatalf("expected the rate limited request to be retried, got: %s", err)
+	}
+
+	if block.ID != "1" || calls != 2 {
+		t.Fatalf("expected 2 calls, got: %d", calls)
+	}
+
+	if d := times[1].Sub(times[0]); d < 900*time.Millisecond {
+		t.Fatalf("expected the retry to wait for Retry-After, waited %s", d)
+	}
+}
+
+func TestClientRateLimitedError(t *testing.T) {
+	var calls int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	c := newClient(srv.URL, "key", testClientOptions())
+
+	_, err := c.createBlock(context.Background(), defaultWorld, blockRequest{Material: "minecraft:stone"})
+	if !IsRateLimited(err) {
+		t.Fatalf("expected a rate limited error, got: %v", err)
+	}
+
+	if calls != 4 {
+		t.Fatalf("expected the request to be retried max_retries times, got %d calls", calls)
+	}
+}
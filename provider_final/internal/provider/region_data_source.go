@@ -0,0 +1,294 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/telemetry"
+)
+
+// maxRegionVolume limits the number of blocks a single region can read.
+const maxRegionVolume = maxFillVolume
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &RegionDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &RegionDataSource{}
+
+func NewRegionDataSource() datasource.DataSource {
+	return &RegionDataSource{}
+}
+
+// RegionDataSource defines the data source implementation.
+type RegionDataSource struct {
+	minecraftClient *client
+}
+
+// RegionDataSourceModel describes the data source data model.
+type RegionDataSourceModel struct {
+	StartX         types.Number `tfsdk:"start_x"`
+	StartY         types.Number `tfsdk:"start_y"`
+	StartZ         types.Number `tfsdk:"start_z"`
+	EndX           types.Number `tfsdk:"end_x"`
+	EndY           types.Number `tfsdk:"end_y"`
+	EndZ           types.Number `tfsdk:"end_z"`
+	Parallelism    types.Int64  `tfsdk:"parallelism"`
+	Blocks         types.List   `tfsdk:"blocks"`
+	MaterialCounts types.Map    `tfsdk:"material_counts"`
+	AllAir         types.Bool   `tfsdk:"all_air"`
+	World          types.String `tfsdk:"world"`
+	Id             types.String `tfsdk:"id"`
+}
+
+var regionBlockAttrTypes = map[string]attr.Type{
+	"x":        types.NumberType,
+	"y":        types.NumberType,
+	"z":        types.NumberType,
+	"material": types.StringType,
+	"state":    types.MapType{ElemType: types.StringType},
+}
+
+func (d *RegionDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_region"
+}
+
+func (d *RegionDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	corner := func(description string) schema.NumberAttribute {
+		return schema.NumberAttribute{
+			MarkdownDescription: description,
+			Required:            true,
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Reads every block in the cuboid between two corners",
+
+		Attributes: map[string]schema.Attribute{
+			"start_x": corner("X coordinate of the first corner"),
+			"start_y": corner("Y coordinate of the first corner"),
+			"start_z": corner("Z coordinate of the first corner"),
+			"end_x":   corner("X coordinate of the opposite corner"),
+			"end_y":   corner("Y coordinate of the opposite corner"),
+			"end_z":   corner("Z coordinate of the opposite corner"),
+			"parallelism": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Maximum number of concurrent requests used when the server does not support reading a region in a single request. Defaults to `%d`.", defaultRegionWorkers),
+				Optional:            true,
+			},
+			"blocks": schema.ListNestedAttribute{
+				MarkdownDescription: "Blocks in the region ordered by x, y then z",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"x": schema.NumberAttribute{
+							MarkdownDescription: "X coordinate of the block",
+							Computed:            true,
+						},
+						"y": schema.NumberAttribute{
+							MarkdownDescription: "Y coordinate of the block",
+							Computed:            true,
+						},
+						"z": schema.NumberAttribute{
+							MarkdownDescription: "Z coordinate of the block",
+							Computed:            true,
+						},
+						"material": schema.StringAttribute{
+							MarkdownDescription: "Material of the block",
+							Computed:            true,
+						},
+						"state": schema.MapAttribute{
+							MarkdownDescription: "Block state properties such as `facing`, `half` or `axis`",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"material_counts": schema.MapAttribute{
+				MarkdownDescription: "Number of blocks of each material in the region",
+				ElementType:         types.Int64Type,
+				Computed:            true,
+			},
+			"all_air": schema.BoolAttribute{
+				MarkdownDescription: "True when every block in the region is `minecraft:air`",
+				Computed:            true,
+			},
+			"world": schema.StringAttribute{
+				MarkdownDescription: "World to read the region from, e.g. `minecraft:the_nether`. Defaults to the provider `world`.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					worldValidator{},
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Region identifier",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *RegionDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var data RegionDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.Parallelism.IsNull() && !data.Parallelism.IsUnknown() && data.Parallelism.ValueInt64() < 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("parallelism"),
+			"Invalid Parallelism",
+			"Parallelism must be at least 1",
+		)
+	}
+
+	for _, n := range []types.Number{data.StartX, data.StartY, data.StartZ, data.EndX, data.EndY, data.EndZ} {
+		if n.IsUnknown() {
+			return
+		}
+	}
+
+	checkRegionVolume(data.start(), data.end(), &resp.Diagnostics)
+}
+
+// checkRegionVolume adds an error when the region between start and end is
+// larger than a single data source can read.
+func checkRegionVolume(start, end coordinate, diags *diag.Diagnostics) {
+	if v := start.volume(end); v > maxRegionVolume {
+		diags.AddError(
+			"Region Too Large",
+			fmt.Sprintf("The region contains %d blocks, the maximum that can be read by a single data source is %d", v, maxRegionVolume),
+		)
+	}
+}
+
+func (d *RegionDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	minecraftClient, ok := req.ProviderData.(*client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.minecraftClient = minecraftClient
+}
+
+func (d *RegionDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := telemetry.Start(ctx, "data.minecraft_region.Read")
+	defer endOperation(span, &resp.Diagnostics)
+
+	var data RegionDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	workers := defaultRegionWorkers
+	if !data.Parallelism.IsNull() {
+		workers = int(data.Parallelism.ValueInt64())
+	}
+
+	data.World = d.minecraftClient.resolveWorld(data.World)
+
+	// the volume is not checked by ValidateConfig when a corner is unknown
+	checkRegionVolume(data.start(), data.end(), &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	blocks, err := d.minecraftClient.getRegion(ctx, data.World.ValueString(), data.start(), data.end(), workers)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read region", err)
+		return
+	}
+
+	lo, hi := data.start().bounds(data.end())
+	data.Id = types.StringValue(worldID(data.World.ValueString(), fmt.Sprintf("%s:%s", lo, hi)))
+
+	resp.Diagnostics.Append(data.setBlocks(ctx, blocks)...)
+
+	tflog.Trace(ctx, "read a data source", map[string]interface{}{
+		"blocks": len(blocks),
+	})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (m RegionDataSourceModel) start() coordinate {
+	return coordinate{intValue(m.StartX), intValue(m.StartY), intValue(m.StartZ)}
+}
+
+func (m RegionDataSourceModel) end() coordinate {
+	return coordinate{intValue(m.EndX), intValue(m.EndY), intValue(m.EndZ)}
+}
+
+// setBlocks sets the blocks and the summary attributes from the blocks read
+// from the server.
+func (m *RegionDataSourceModel) setBlocks(ctx context.Context, blocks map[coordinate]*blockResponse) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	counts := map[string]int64{}
+	values := []attr.Value{}
+
+	for _, c := range sortedCoordinates(blocks) {
+		b := blocks[c]
+
+		material := b.Material
+		if material == "" {
+			material = airMaterial
+		}
+
+		counts[material]++
+
+		state, d := types.MapValueFrom(ctx, types.StringType, b.blockState())
+		diags.Append(d...)
+
+		values = append(values, types.ObjectValueMust(regionBlockAttrTypes, map[string]attr.Value{
+			"x":        numberValue(c.X),
+			"y":        numberValue(c.Y),
+			"z":        numberValue(c.Z),
+			"material": types.StringValue(material),
+			"state":    state,
+		}))
+	}
+
+	list, d := types.ListValue(types.ObjectType{AttrTypes: regionBlockAttrTypes}, values)
+	diags.Append(d...)
+	m.Blocks = list
+
+	countMap, d := types.MapValueFrom(ctx, types.Int64Type, counts)
+	diags.Append(d...)
+	m.MaterialCounts = countMap
+
+	m.AllAir = types.BoolValue(len(counts) == 1 && counts[airMaterial] > 0)
+
+	return diags
+}
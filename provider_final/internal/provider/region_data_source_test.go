@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"math"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRegionDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				PreConfig: func() {
+					if testAccServer != nil {
+						testAccServer.setBlock(-1272, 23, 288, "minecraft:stone")
+					}
+				},
+				Config: testAccRegionDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.minecraft_region.test", "blocks.#", "8"),
+					resource.TestCheckResourceAttr("data.minecraft_region.test", "all_air", "false"),
+					resource.TestCheckResourceAttrSet("data.minecraft_region.test", "material_counts.minecraft:stone"),
+				),
+			},
+		},
+	})
+}
+
+const testAccRegionDataSourceConfig = `
+data "minecraft_region" "test" {
+  start_x = -1272
+  start_y = 23
+  start_z = 288
+  end_x   = -1271
+  end_y   = 24
+  end_z   = 289
+}
+`
+
+func TestCheckRegionVolume(t *testing.T) {
+	var diags diag.Diagnostics
+	checkRegionVolume(coordinate{0, 0, 0}, coordinate{31, 31, 31}, &diags)
+
+	if diags.HasError() {
+		t.Fatalf("expected a region at the limit to be allowed, got: %v", diags)
+	}
+
+	// corners known only at apply can be far enough apart to overflow
+	checkRegionVolume(coordinate{math.MinInt, 0, 0}, coordinate{math.MaxInt, 0, 0}, &diags)
+
+	if !diags.HasError() {
+		t.Fatal("expected an error for a region larger than the limit")
+	}
+}
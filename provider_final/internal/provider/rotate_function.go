@@ -0,0 +1,69 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var (
+	_ function.Function = RotateFunction{}
+)
+
+func NewRotateFunction() function.Function {
+	return RotateFunction{}
+}
+
+type RotateFunction struct{}
+
+func (r RotateFunction) Metadata(_ context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "rotate"
+}
+
+func (r RotateFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Rotate a position around an origin",
+		MarkdownDescription: "Returns the position rotated clockwise around the vertical axis through `origin`, the same way `minecraft_schema` rotates a structure. The Y coordinate is unchanged.",
+		Parameters: []function.Parameter{
+			function.ObjectParameter{
+				Name:                "pos",
+				MarkdownDescription: "Position to rotate with `x`, `y` and `z` attributes",
+				AttributeTypes:      positionAttrTypes,
+			},
+			function.ObjectParameter{
+				Name:                "origin",
+				MarkdownDescription: "Position to rotate around with `x`, `y` and `z` attributes",
+				AttributeTypes:      positionAttrTypes,
+			},
+			function.Int64Parameter{
+				Name:                "degrees",
+				MarkdownDescription: "Clockwise rotation in degrees, must be a multiple of 90, negative values rotate anti-clockwise",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: positionAttrTypes,
+		},
+	}
+}
+
+func (r RotateFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var pos, origin positionModel
+	var degrees int64
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &pos, &origin, &degrees))
+
+	if resp.Error != nil {
+		return
+	}
+
+	rotated, err := pos.coordinate().rotate(origin.coordinate(), int(degrees))
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(2, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, newPositionModel(rotated)))
+}
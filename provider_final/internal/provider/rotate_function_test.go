@@ -0,0 +1,91 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestRotateFunction_Known(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				locals {
+					origin    = { x = 10, y = 64, z = 10 }
+					clockwise = provider::minecraft::rotate({ x = 11, y = 65, z = 13 }, local.origin, 90)
+					anti      = provider::minecraft::rotate({ x = 11, y = 65, z = 13 }, local.origin, -90)
+				}
+
+				output "clockwise" {
+					value = "${local.clockwise.x},${local.clockwise.y},${local.clockwise.z}"
+				}
+
+				output "anti" {
+					value = "${local.anti.x},${local.anti.y},${local.anti.z}"
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("clockwise", "7,65,11"),
+					resource.TestCheckOutput("anti", "13,65,9"),
+				),
+			},
+		},
+	})
+}
+
+func TestRotateFunction_InvalidRotation(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "test" {
+					value = provider::minecraft::rotate({ x = 1, y = 0, z = 0 }, { x = 0, y = 0, z = 0 }, 45)
+				}
+				`,
+				ExpectError: regexp.MustCompile(`invalid rotation 45`),
+			},
+		},
+	})
+}
+
+func TestCoordinateRotate(t *testing.T) {
+	origin := coordinate{10, 64, 10}
+	pos := coordinate{11, 65, 13}
+
+	for degrees, want := range map[int]coordinate{
+		0:    {11, 65, 13},
+		90:   {7, 65, 11},
+		180:  {9, 65, 7},
+		270:  {13, 65, 9},
+		-90:  {13, 65, 9},
+		450:  {7, 65, 11},
+		-360: {11, 65, 13},
+	} {
+		got, err := pos.rotate(origin, degrees)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got != want {
+			t.Fatalf("rotation %d: expected %s, got %s", degrees, want, got)
+		}
+	}
+
+	if _, err := pos.rotate(origin, 45); err == nil {
+		t.Fatal("expected an error for an invalid rotation")
+	}
+}
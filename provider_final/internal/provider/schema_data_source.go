@@ -0,0 +1,214 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/telemetry"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &SchemaDataSource{}
+
+func NewSchemaDataSource() datasource.DataSource {
+	return &SchemaDataSource{}
+}
+
+// SchemaDataSource defines the data source implementation.
+type SchemaDataSource struct {
+	minecraftClient *client
+}
+
+// SchemaPlacementModel describes a schema placed on the server, it is used by
+// both the minecraft_schema and minecraft_schemas data sources.
+type SchemaPlacementModel struct {
+	Id      types.String `tfsdk:"id"`
+	StartX  types.Number `tfsdk:"start_x"`
+	StartY  types.Number `tfsdk:"start_y"`
+	StartZ  types.Number `tfsdk:"start_z"`
+	EndX    types.Number `tfsdk:"end_x"`
+	EndY    types.Number `tfsdk:"end_y"`
+	EndZ    types.Number `tfsdk:"end_z"`
+	Width   types.Number `tfsdk:"width"`
+	Height  types.Number `tfsdk:"height"`
+	Length  types.Number `tfsdk:"length"`
+	CenterX types.Number `tfsdk:"center_x"`
+	CenterY types.Number `tfsdk:"center_y"`
+	CenterZ types.Number `tfsdk:"center_z"`
+	World   types.String `tfsdk:"world"`
+}
+
+var schemaPlacementAttrTypes = map[string]attr.Type{
+	"id":       types.StringType,
+	"start_x":  types.NumberType,
+	"start_y":  types.NumberType,
+	"start_z":  types.NumberType,
+	"end_x":    types.NumberType,
+	"end_y":    types.NumberType,
+	"end_z":    types.NumberType,
+	"width":    types.NumberType,
+	"height":   types.NumberType,
+	"length":   types.NumberType,
+	"center_x": types.NumberType,
+	"center_y": types.NumberType,
+	"center_z": types.NumberType,
+	"world":    types.StringType,
+}
+
+// schemaPlacementAttributes returns the computed attributes describing a
+// placed schema.
+func schemaPlacementAttributes() map[string]schema.Attribute {
+	computed := func(description string) schema.NumberAttribute {
+		return schema.NumberAttribute{
+			MarkdownDescription: description,
+			Computed:            true,
+		}
+	}
+
+	return map[string]schema.Attribute{
+		"start_x":  computed("Start X coordinate of the placed structure's bounding box"),
+		"start_y":  computed("Start Y coordinate of the placed structure's bounding box"),
+		"start_z":  computed("Start Z coordinate of the placed structure's bounding box"),
+		"end_x":    computed("End X coordinate of the placed structure's bounding box"),
+		"end_y":    computed("End Y coordinate of the placed structure's bounding box"),
+		"end_z":    computed("End Z coordinate of the placed structure's bounding box"),
+		"width":    computed("Number of blocks the structure occupies along the X axis"),
+		"height":   computed("Number of blocks the structure occupies along the Y axis"),
+		"length":   computed("Number of blocks the structure occupies along the Z axis"),
+		"center_x": computed("X coordinate of the center of the bounding box"),
+		"center_y": computed("Y coordinate of the center of the bounding box"),
+		"center_z": computed("Z coordinate of the center of the bounding box"),
+		"world": schema.StringAttribute{
+			MarkdownDescription: "World the schema is placed in",
+			Computed:            true,
+		},
+	}
+}
+
+func (d *SchemaDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_schema"
+}
+
+func (d *SchemaDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := schemaPlacementAttributes()
+	attributes["id"] = schema.StringAttribute{
+		MarkdownDescription: "Undo identifier of the placed schema, the `id` of a `minecraft_schema` resource",
+		Required:            true,
+	}
+	attributes["world"] = schema.StringAttribute{
+		MarkdownDescription: "World the schema is placed in, e.g. `minecraft:the_nether`. Defaults to the world in `id`, identifiers without a world are in `minecraft:overworld`. Must match the world in `id` when both are set.",
+		Optional:            true,
+		Computed:            true,
+		Validators: []validator.String{
+			worldValidator{},
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Looks up the region occupied by a placed schema",
+
+		Attributes: attributes,
+	}
+}
+
+func (d *SchemaDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	minecraftClient, ok := req.ProviderData.(*client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.minecraftClient = minecraftClient
+}
+
+func (d *SchemaDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := telemetry.Start(ctx, "data.minecraft_schema.Read")
+	defer endOperation(span, &resp.Diagnostics)
+
+	var data SchemaPlacementModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// identifiers without a world are in the default world, unless the
+	// configuration sets one
+	world, undoID := splitWorldID(data.Id.ValueString())
+	switch {
+	case world != "" && !data.World.IsNull() && !data.World.IsUnknown() && data.World.ValueString() != world:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("world"),
+			"Conflicting World",
+			fmt.Sprintf("The world %q does not match the world %q of the schema identifier %q, remove the 'world' attribute or use an identifier without a world.", data.World.ValueString(), world, data.Id.ValueString()),
+		)
+
+		return
+	case world == "":
+		world = worldValue(data.World)
+	}
+
+	details, err := d.minecraftClient.getSchemaDetails(ctx, world, undoID)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read schema", err)
+		return
+	}
+
+	data = newSchemaPlacementModel(data.Id.ValueString(), world, details)
+
+	tflog.Trace(ctx, "read a data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// newSchemaPlacementModel describes the placement id in world with the
+// bounding box d.
+func newSchemaPlacementModel(id, world string, d *schemaDetailsResponse) SchemaPlacementModel {
+	lo, hi := coordinate{d.StartX, d.StartY, d.StartZ}.bounds(coordinate{d.EndX, d.EndY, d.EndZ})
+
+	center := func(a, b int) types.Number {
+		return types.NumberValue(big.NewFloat(float64(a+b) / 2))
+	}
+
+	return SchemaPlacementModel{
+		Id:      types.StringValue(id),
+		StartX:  numberValue(d.StartX),
+		StartY:  numberValue(d.StartY),
+		StartZ:  numberValue(d.StartZ),
+		EndX:    numberValue(d.EndX),
+		EndY:    numberValue(d.EndY),
+		EndZ:    numberValue(d.EndZ),
+		Width:   numberValue(hi.X - lo.X + 1),
+		Height:  numberValue(hi.Y - lo.Y + 1),
+		Length:  numberValue(hi.Z - lo.Z + 1),
+		CenterX: center(lo.X, hi.X),
+		CenterY: center(lo.Y, hi.Y),
+		CenterZ: center(lo.Z, hi.Z),
+		World:   types.StringValue(world),
+	}
+}
@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSchemaDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccSchemaDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.minecraft_schema.car", "start_x", "minecraft_schema.car", "start_x"),
+					resource.TestCheckResourceAttrPair("data.minecraft_schema.car", "end_z", "minecraft_schema.car", "end_z"),
+					resource.TestCheckResourceAttrSet("data.minecraft_schema.car", "width"),
+					resource.TestCheckResourceAttrSet("data.minecraft_schema.car", "center_x"),
+					resource.TestCheckResourceAttr("data.minecraft_schemas.all", "schemas.#", "1"),
+					resource.TestCheckResourceAttrPair("data.minecraft_schemas.all", "schemas.0.id", "minecraft_schema.car", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSchemaDataSourceConflictingWorld(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "minecraft_schema" "car" {
+  id    = "minecraft:the_nether/undo-1"
+  world = "minecraft:the_end"
+}
+`,
+				ExpectError: regexp.MustCompile("Conflicting World"),
+			},
+		},
+	})
+}
+
+func TestAccSchemasDataSourceUnsupported(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+
+			// a live server cannot be made to drop the endpoint
+			if testAccServer == nil {
+				t.Skip("requires the fake Minecraft API")
+			}
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig:   func() { testAccServer.disableExtensions() },
+				Config:      `data "minecraft_schemas" "all" {}`,
+				ExpectError: regexp.MustCompile(`does not support listing schemas`),
+			},
+		},
+	})
+}
+
+func TestNewSchemaPlacementModel(t *testing.T) {
+	m := newSchemaPlacementModel("undo-1", defaultWorld, &schemaDetailsResponse{
+		StartX: -4, StartY: 0, StartZ: 10,
+		EndX: 0, EndY: 3, EndZ: 1,
+	})
+
+	for name, tt := range map[string]struct {
+		got  int
+		want int
+	}{
+		"width":  {intValue(m.Width), 5},
+		"height": {intValue(m.Height), 4},
+		"length": {intValue(m.Length), 10},
+	} {
+		if tt.got != tt.want {
+			t.Fatalf("expected %s %d, got %d", name, tt.want, tt.got)
+		}
+	}
+
+	if f, _ := m.CenterX.ValueBigFloat().Float64(); f != -2 {
+		t.Fatalf("expected center_x -2, got %v", f)
+	}
+
+	if f, _ := m.CenterY.ValueBigFloat().Float64(); f != 1.5 {
+		t.Fatalf("expected center_y 1.5, got %v", f)
+	}
+}
+
+const testAccSchemaDataSourceConfig = `
+resource "minecraft_schema" "car" {
+  x = 0
+  y = 0
+  z = 0
+  rotation = 90
+  schema = "../../schemas/car.zip"
+}
+
+data "minecraft_schema" "car" {
+  id = minecraft_schema.car.id
+}
+
+data "minecraft_schemas" "all" {
+  depends_on = [minecraft_schema.car]
+}
+`
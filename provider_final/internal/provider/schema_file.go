@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+)
+
+// schemaFileName is the file within a schema zip that lists its voxels.
+const schemaFileName = "schema.json"
+
+// readSchemaZip parses the voxels from the content of a schema zip.
+func readSchemaZip(data []byte) ([]voxel, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema zip: %s", err)
+	}
+
+	f, err := zr.Open(schemaFileName)
+	if err != nil {
+		return nil, fmt.Errorf("schema zip does not contain %s: %s", schemaFileName, err)
+	}
+	defer f.Close()
+
+	voxels, err := readVoxels(f)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(voxels) == 0 {
+		return nil, fmt.Errorf("%s does not contain any blocks", schemaFileName)
+	}
+
+	return voxels, nil
+}
+
+// schemaBounds returns the minimum and maximum corners of the region the
+// voxels occupy when placed at origin with the given rotation.
+func schemaBounds(voxels []voxel, origin coordinate, rotation int) (coordinate, coordinate, error) {
+	var lo, hi coordinate
+
+	for i, v := range voxels {
+		dx, dz, err := rotateOffset(v.X, v.Z, rotation)
+		if err != nil {
+			return lo, hi, err
+		}
+
+		c := coordinate{origin.X + dx, origin.Y + v.Y, origin.Z + dz}
+		if i == 0 {
+			lo, hi = c, c
+			continue
+		}
+
+		lo, _ = lo.bounds(c)
+		_, hi = hi.bounds(c)
+	}
+
+	return lo, hi, nil
+}
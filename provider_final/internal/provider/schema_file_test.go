@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestReadSchemaZip(t *testing.T) {
+	car, err := os.ReadFile("../../schemas/car.zip")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	voxels, err := readSchemaZip(car)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(voxels) == 0 {
+		t.Fatal("expected voxels in car.zip")
+	}
+
+	for _, v := range voxels {
+		if err := validateMaterial(v.Material); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := readSchemaZip([]byte("not a zip")); err == nil {
+		t.Fatal("expected an error for a corrupt archive")
+	}
+
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+	zw.Create("other.json")
+	zw.Close()
+
+	if _, err := readSchemaZip(buf.Bytes()); err == nil || !strings.Contains(err.Error(), schemaFileName) {
+		t.Fatalf("expected an error for a missing %s, got %v", schemaFileName, err)
+	}
+}
+
+func TestSchemaBounds(t *testing.T) {
+	voxels := []voxel{
+		{X: 0, Y: 0, Z: 0},
+		{X: 2, Y: 1, Z: 4},
+	}
+
+	origin := coordinate{10, 20, 30}
+
+	tests := []struct {
+		rotation int
+		lo, hi   coordinate
+	}{
+		{0, coordinate{10, 20, 30}, coordinate{12, 21, 34}},
+		{90, coordinate{6, 20, 30}, coordinate{10, 21, 32}},
+		{180, coordinate{8, 20, 26}, coordinate{10, 21, 30}},
+		{270, coordinate{10, 20, 28}, coordinate{14, 21, 30}},
+	}
+
+	for _, tt := range tests {
+		lo, hi, err := schemaBounds(voxels, origin, tt.rotation)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if lo != tt.lo || hi != tt.hi {
+			t.Fatalf("rotation %d: expected %s to %s, got %s to %s", tt.rotation, tt.lo, tt.hi, lo, hi)
+		}
+	}
+
+	if _, _, err := schemaBounds(voxels, origin, 45); err == nil {
+		t.Fatal("expected an error for an invalid rotation")
+	}
+}
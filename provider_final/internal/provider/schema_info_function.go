@@ -0,0 +1,157 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ function.Function = SchemaInfoFunction{}
+)
+
+var schemaDimensionsAttrTypes = map[string]attr.Type{
+	"width":  types.Int64Type,
+	"height": types.Int64Type,
+	"length": types.Int64Type,
+}
+
+var schemaFootprintAttrTypes = map[string]attr.Type{
+	"start":  types.ObjectType{AttrTypes: positionAttrTypes},
+	"end":    types.ObjectType{AttrTypes: positionAttrTypes},
+	"width":  types.Int64Type,
+	"height": types.Int64Type,
+	"length": types.Int64Type,
+}
+
+var schemaInfoAttrTypes = map[string]attr.Type{
+	"dimensions":  types.ObjectType{AttrTypes: schemaDimensionsAttrTypes},
+	"footprint":   types.ObjectType{AttrTypes: schemaFootprintAttrTypes},
+	"block_count": types.Int64Type,
+	"materials":   types.MapType{ElemType: types.Int64Type},
+	"hash":        types.StringType,
+}
+
+// schemaDimensionsModel is the size of a schema in blocks.
+type schemaDimensionsModel struct {
+	Width  int64 `tfsdk:"width"`
+	Height int64 `tfsdk:"height"`
+	Length int64 `tfsdk:"length"`
+}
+
+// schemaFootprintModel is the region a rotated schema occupies relative to
+// the position it is placed at.
+type schemaFootprintModel struct {
+	Start  positionModel `tfsdk:"start"`
+	End    positionModel `tfsdk:"end"`
+	Width  int64         `tfsdk:"width"`
+	Height int64         `tfsdk:"height"`
+	Length int64         `tfsdk:"length"`
+}
+
+// schemaInfoModel is the result of the schema_info function.
+type schemaInfoModel struct {
+	Dimensions schemaDimensionsModel `tfsdk:"dimensions"`
+	Footprint  schemaFootprintModel  `tfsdk:"footprint"`
+	BlockCount int64                 `tfsdk:"block_count"`
+	Materials  map[string]int64      `tfsdk:"materials"`
+	Hash       string                `tfsdk:"hash"`
+}
+
+func NewSchemaInfoFunction() function.Function {
+	return SchemaInfoFunction{}
+}
+
+type SchemaInfoFunction struct{}
+
+func (r SchemaInfoFunction) Metadata(_ context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "schema_info"
+}
+
+func (r SchemaInfoFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Inspect a schema zip",
+		MarkdownDescription: "Reads a schema zip and returns its `dimensions`, the `footprint` it occupies relative to the placement position after `rotation`, the `block_count` and `materials` histogram of the blocks other than `minecraft:air`, and the `hash` stored in the `schema_hash` attribute of `minecraft_schema`",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "path",
+				MarkdownDescription: "Path to a local schema zip file",
+			},
+			function.Int64Parameter{
+				Name:                "rotation",
+				MarkdownDescription: "Rotation the schema is placed with, one of `0`, `90`, `180` or `270`",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: schemaInfoAttrTypes,
+		},
+	}
+}
+
+func (r SchemaInfoFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var path string
+	var rotation int64
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &path, &rotation))
+
+	if resp.Error != nil {
+		return
+	}
+
+	data, err := schemaSource{Path: path}.read(ctx)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	voxels, err := readSchemaZip(data)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	lo, hi, err := schemaBounds(voxels, coordinate{}, 0)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	rlo, rhi, err := schemaBounds(voxels, coordinate{}, int(rotation))
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+
+	result := schemaInfoModel{
+		Dimensions: schemaDimensionsModel{
+			Width:  int64(hi.X - lo.X + 1),
+			Height: int64(hi.Y - lo.Y + 1),
+			Length: int64(hi.Z - lo.Z + 1),
+		},
+		Footprint: schemaFootprintModel{
+			Start:  newPositionModel(rlo),
+			End:    newPositionModel(rhi),
+			Width:  int64(rhi.X - rlo.X + 1),
+			Height: int64(rhi.Y - rlo.Y + 1),
+			Length: int64(rhi.Z - rlo.Z + 1),
+		},
+		Materials: map[string]int64{},
+		Hash:      calculateHash(data),
+	}
+
+	for _, v := range voxels {
+		if v.Material == "" || v.Material == airMaterial {
+			continue
+		}
+
+		result.BlockCount++
+		result.Materials[v.Material]++
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, result))
+}
@@ -0,0 +1,95 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestSchemaInfoFunction_Known(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				locals {
+					car = provider::minecraft::schema_info("../../schemas/car.zip", 90)
+				}
+
+				output "dimensions" {
+					value = "${local.car.dimensions.width}x${local.car.dimensions.height}x${local.car.dimensions.length}"
+				}
+
+				output "footprint" {
+					value = "${local.car.footprint.start.x},${local.car.footprint.start.z} to ${local.car.footprint.end.x},${local.car.footprint.end.z}"
+				}
+
+				output "block_count" {
+					value = local.car.block_count
+				}
+
+				output "wool" {
+					value = local.car.materials["minecraft:black_wool"]
+				}
+
+				output "hash" {
+					value = local.car.hash
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("dimensions", "9x11x5"),
+					resource.TestCheckOutput("footprint", "-4,0 to 0,8"),
+					resource.TestCheckOutput("block_count", "74"),
+					resource.TestCheckOutput("wool", "4"),
+					resource.TestCheckOutput("hash", "GlcbIxS+tBGsVVkdctmHkozo40ER0oJfkzkvOxBLvGA="),
+				),
+			},
+		},
+	})
+}
+
+func TestSchemaInfoFunction_InvalidRotation(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "test" {
+					value = provider::minecraft::schema_info("../../schemas/car.zip", 45)
+				}
+				`,
+				ExpectError: regexp.MustCompile(`invalid rotation 45`),
+			},
+		},
+	})
+}
+
+func TestSchemaInfoFunction_MissingFile(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "test" {
+					value = provider::minecraft::schema_info("../../schemas/missing.zip", 0)
+				}
+				`,
+				ExpectError: regexp.MustCompile(`unable to open schema file`),
+			},
+		},
+	})
+}
@@ -0,0 +1,171 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// Values for the provider schema_overlap attribute.
+const (
+	schemaOverlapError   = "error"
+	schemaOverlapWarning = "warning"
+	schemaOverlapIgnore  = "ignore"
+)
+
+// schemaPlacement is the region occupied by a placed or planned schema.
+type schemaPlacement struct {
+	// ID is the resource identifier of the placement, the undo ID prefixed
+	// with the world, empty when it has not been placed.
+	ID string
+	// Key identifies the resource that planned a new placement, so that
+	// planning the same resource again replaces its earlier entry instead
+	// of overlapping it. Placements with an ID, or replacing one, are
+	// identified by that ID.
+	Key        string
+	World      string
+	Start, End coordinate
+}
+
+func (p schemaPlacement) String() string {
+	if p.ID == "" {
+		return fmt.Sprintf("planned schema from %s to %s", p.Start, p.End)
+	}
+
+	return fmt.Sprintf("schema %s from %s to %s", p.ID, p.Start, p.End)
+}
+
+// intersects returns true when p and o occupy a common block in the same
+// world.
+func (p schemaPlacement) intersects(o schemaPlacement) bool {
+	return p.World == o.World && boxesIntersect(p.Start, p.End, o.Start, o.End)
+}
+
+// schemaPlacements tracks the schemas planned by a provider instance along
+// with the placements the server already knows about. Terraform plans every
+// resource with the same configured provider, so each schema is checked
+// against all the schemas planned before it.
+type schemaPlacements struct {
+	mu   sync.Mutex
+	mode string
+
+	listed   map[string]bool
+	placed   []schemaPlacement
+	planned  []schemaPlacement
+	replaced map[string]bool
+
+	// plannedKeys is the index in planned of each identified placement.
+	plannedKeys map[string]int
+}
+
+func newSchemaPlacements() *schemaPlacements {
+	return &schemaPlacements{
+		mode:        schemaOverlapError,
+		listed:      map[string]bool{},
+		replaced:    map[string]bool{},
+		plannedKeys: map[string]int{},
+	}
+}
+
+// plan records the planned placement p under key, replacing the placement
+// previously planned with the same key.
+func (s *schemaPlacements) plan(key string, p schemaPlacement) {
+	if i, ok := s.plannedKeys[key]; ok && key != "" {
+		s.planned[i] = p
+		return
+	}
+
+	if key != "" {
+		s.plannedKeys[key] = len(s.planned)
+	}
+
+	s.planned = append(s.planned, p)
+}
+
+// checkSchemaPlacement records the planned placement p and returns every
+// placement it intersects. replaces is the identifier of the placement p is
+// replacing, which is undone on apply and is never reported. The error is
+// set when the placed schemas could not be listed, the planned placements
+// are still checked.
+func (c *client) checkSchemaPlacement(ctx context.Context, p schemaPlacement, replaces string) ([]schemaPlacement, error) {
+	s := c.placements
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var listErr error
+	if !s.listed[p.World] {
+		items, err := c.listSchemas(ctx, p.World)
+
+		switch {
+		case err == nil:
+			for _, i := range items {
+				s.placed = append(s.placed, schemaPlacement{
+					ID:    worldID(p.World, i.ID),
+					World: p.World,
+					Start: coordinate{i.StartX, i.StartY, i.StartZ},
+					End:   coordinate{i.EndX, i.EndY, i.EndZ},
+				})
+			}
+
+			s.listed[p.World] = true
+		case IsNotFound(err) || hasStatus(err, http.StatusMethodNotAllowed):
+			// the server does not support listing schemas
+			s.listed[p.World] = true
+		default:
+			listErr = err
+		}
+	}
+
+	if replaces != "" {
+		s.replaced[replaces] = true
+	}
+
+	key := p.Key
+	switch {
+	case p.ID != "":
+		key = p.ID
+	case replaces != "":
+		key = replaces
+	}
+
+	overlaps := []schemaPlacement{}
+
+	// an unchanged placement is only checked against new placements, any
+	// overlap with existing placements was reported when it was planned.
+	// A resource planned again is not checked against its earlier plan.
+	for i, o := range s.planned {
+		if j, ok := s.plannedKeys[key]; ok && key != "" && i == j {
+			continue
+		}
+
+		if (p.ID == "" || o.ID == "") && p.intersects(o) {
+			overlaps = append(overlaps, o)
+		}
+	}
+
+	if p.ID != "" {
+		s.plan(key, p)
+		return overlaps, listErr
+	}
+
+	planned := map[string]bool{}
+	for _, o := range s.planned {
+		planned[o.ID] = true
+	}
+
+	for _, o := range s.placed {
+		if s.replaced[o.ID] || planned[o.ID] {
+			continue
+		}
+
+		if p.intersects(o) {
+			overlaps = append(overlaps, o)
+		}
+	}
+
+	s.plan(key, p)
+
+	return overlaps, listErr
+}
@@ -0,0 +1,200 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSchemaResourceOverlap(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccSchemaOverlapConfig("error", 2),
+				ExpectError: regexp.MustCompile("Schema Placement Overlap"),
+			},
+			{
+				Config: testAccSchemaOverlapConfig("warning", 2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("minecraft_schema.first", "id"),
+					resource.TestCheckResourceAttrSet("minecraft_schema.second", "id"),
+				),
+			},
+			// Moving the second schema away no longer overlaps
+			{
+				Config: testAccSchemaOverlapConfig("error", 100),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("minecraft_schema.second", "x", "100"),
+				),
+			},
+		},
+	})
+}
+
+func TestCheckSchemaPlacement(t *testing.T) {
+	f := newFakeMinecraftServer(t)
+	c := newClient(f.URL, fakeAPIKey, testClientOptions())
+	ctx := context.Background()
+
+	car, err := os.ReadFile("../../schemas/car.zip")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id, err := c.createSchema(ctx, defaultWorld, schemaRequest{Schema: car})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	details, err := c.getSchemaDetails(ctx, defaultWorld, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	placed := schemaPlacement{
+		ID:    id,
+		World: defaultWorld,
+		Start: coordinate{details.StartX, details.StartY, details.StartZ},
+		End:   coordinate{details.EndX, details.EndY, details.EndZ},
+	}
+
+	planned := schemaPlacement{World: defaultWorld, Start: placed.Start, End: placed.Start}
+
+	overlaps, err := c.checkSchemaPlacement(ctx, planned, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(overlaps) != 1 || overlaps[0].ID != id {
+		t.Fatalf("expected an overlap with %s, got %v", id, overlaps)
+	}
+
+	// a placement replacing the placed schema is only checked against the
+	// other planned placements
+	overlaps, err = c.checkSchemaPlacement(ctx, schemaPlacement{World: defaultWorld, Start: placed.End, End: placed.End}, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(overlaps) != 0 {
+		t.Fatalf("expected no overlaps, got %v", overlaps)
+	}
+
+	// the same coordinates in another world do not overlap
+	overlaps, err = c.checkSchemaPlacement(ctx, schemaPlacement{World: "minecraft:the_nether", Start: placed.Start, End: placed.End}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(overlaps) != 0 {
+		t.Fatalf("expected no overlaps in another world, got %v", overlaps)
+	}
+
+	// the unchanged placement does not overlap itself, or the replacement
+	// planned for it earlier, only the other planned placements within its
+	// footprint
+	overlaps, err = c.checkSchemaPlacement(ctx, placed, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(overlaps) != 1 || overlaps[0] != planned {
+		t.Fatalf("expected an overlap with the planned placement, got %v", overlaps)
+	}
+}
+
+func TestCheckSchemaPlacementListUnsupported(t *testing.T) {
+	f := newFakeMinecraftServer(t)
+	f.disableExtensions()
+
+	c := newClient(f.URL, fakeAPIKey, testClientOptions())
+	ctx := context.Background()
+
+	p := schemaPlacement{World: defaultWorld, Start: coordinate{0, 0, 0}, End: coordinate{4, 4, 4}}
+
+	// servers that cannot list schemas only have the planned placements
+	// checked
+	overlaps, err := c.checkSchemaPlacement(ctx, p, "")
+	if err != nil {
+		t.Fatalf("expected the unsupported list to be ignored, got: %s", err)
+	}
+
+	if len(overlaps) != 0 {
+		t.Fatalf("expected no overlaps, got %v", overlaps)
+	}
+
+	overlaps, err = c.checkSchemaPlacement(ctx, p, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(overlaps) != 1 {
+		t.Fatalf("expected an overlap with the planned placement, got %v", overlaps)
+	}
+
+	if n := f.requestCount(http.MethodGet, "/v1/schema"); n != 1 {
+		t.Fatalf("expected the schemas to be listed once, got %d requests", n)
+	}
+}
+
+func TestCheckSchemaPlacementPlannedTwice(t *testing.T) {
+	f := newFakeMinecraftServer(t)
+	c := newClient(f.URL, fakeAPIKey, testClientOptions())
+	ctx := context.Background()
+
+	p := schemaPlacement{Key: "first", World: defaultWorld, Start: coordinate{0, 0, 0}, End: coordinate{4, 4, 4}}
+
+	for i := 0; i < 2; i++ {
+		overlaps, err := c.checkSchemaPlacement(ctx, p, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(overlaps) != 0 {
+			t.Fatalf("expected a resource planned again not to overlap itself, got %v", overlaps)
+		}
+	}
+
+	other := p
+	other.Key = "second"
+
+	overlaps, err := c.checkSchemaPlacement(ctx, other, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(overlaps) != 1 {
+		t.Fatalf("expected another resource at the same position to overlap once, got %v", overlaps)
+	}
+}
+
+func testAccSchemaOverlapConfig(mode string, x int) string {
+	return fmt.Sprintf(`
+  provider "minecraft" {
+	  schema_overlap = %q
+	}
+
+  resource "minecraft_schema" "first" {
+	  x = 0
+	  y = 0
+	  z = 0
+	  rotation = 0
+	  schema = "../../schemas/car.zip"
+	}
+
+  resource "minecraft_schema" "second" {
+	  x = %d
+	  y = 0
+	  z = 0
+	  rotation = 0
+	  schema = "../../schemas/car.zip"
+	}
+  `, mode, x)
+}
@@ -6,24 +6,27 @@ package provider
 import (
 	"context"
 	"crypto/sha256"
-	"encoding/base64"
+	"encoding/hex"
 	"fmt"
-	"io"
-	"os"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/numberplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/telemetry"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &SchemaResource{}
 var _ resource.ResourceWithImportState = &SchemaResource{}
+var _ resource.ResourceWithModifyPlan = &SchemaResource{}
+var _ resource.ResourceWithValidateConfig = &SchemaResource{}
 
 func NewSchemaResource() resource.Resource {
 	return &SchemaResource{}
@@ -36,13 +39,23 @@ type SchemaResource struct {
 
 // SchemaResourceModel describes the resource data model.
 type SchemaResourceModel struct {
-	X          types.Number `tfsdk:"x"`
-	Y          types.Number `tfsdk:"y"`
-	Z          types.Number `tfsdk:"z"`
-	Rotation   types.Number `tfsdk:"rotation"`
-	Schema     types.String `tfsdk:"schema"`
-	SchemaHash types.String `tfsdk:"schema_hash"`
-	Id         types.String `tfsdk:"id"`
+	X            types.Number `tfsdk:"x"`
+	Y            types.Number `tfsdk:"y"`
+	Z            types.Number `tfsdk:"z"`
+	Rotation     types.Number `tfsdk:"rotation"`
+	Schema       types.String `tfsdk:"schema"`
+	SchemaBase64 types.String `tfsdk:"schema_base64"`
+	SchemaURL    types.String `tfsdk:"schema_url"`
+	SchemaSHA256 types.String `tfsdk:"schema_sha256"`
+	SchemaHash   types.String `tfsdk:"schema_hash"`
+	StartX       types.Number `tfsdk:"start_x"`
+	StartY       types.Number `tfsdk:"start_y"`
+	StartZ       types.Number `tfsdk:"start_z"`
+	EndX         types.Number `tfsdk:"end_x"`
+	EndY         types.Number `tfsdk:"end_y"`
+	EndZ         types.Number `tfsdk:"end_z"`
+	World        types.String `tfsdk:"world"`
+	Id           types.String `tfsdk:"id"`
 }
 
 func (r *SchemaResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -52,55 +65,101 @@ func (r *SchemaResource) Metadata(ctx context.Context, req resource.MetadataRequ
 func (r *SchemaResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
-		MarkdownDescription: "Example resource",
+		MarkdownDescription: "Places a structure from a schema zip in the Minecraft world. Moving, rotating or changing the schema places the structure again and undoes the previous placement, destroying the resource undoes the placement and restores the original terrain.",
 
 		Attributes: map[string]schema.Attribute{
 			"x": schema.NumberAttribute{
-				MarkdownDescription: "Example configurable attribute",
+				MarkdownDescription: "X coordinate the schema origin is placed at",
 				Required:            true,
-				PlanModifiers: []planmodifier.Number{
-					numberplanmodifier.RequiresReplace(),
-				},
 			},
 			"y": schema.NumberAttribute{
-				MarkdownDescription: "Example configurable attribute",
+				MarkdownDescription: "Y coordinate the schema origin is placed at",
 				Required:            true,
-				PlanModifiers: []planmodifier.Number{
-					numberplanmodifier.RequiresReplace(),
-				},
 			},
 			"z": schema.NumberAttribute{
-				MarkdownDescription: "Example configurable attribute",
+				MarkdownDescription: "Z coordinate the schema origin is placed at",
 				Required:            true,
-				PlanModifiers: []planmodifier.Number{
-					numberplanmodifier.RequiresReplace(),
-				},
 			},
 			"rotation": schema.NumberAttribute{
-				MarkdownDescription: "Example configurable attribute",
+				MarkdownDescription: "Clockwise rotation of the schema around its origin in degrees, one of `0`, `90`, `180` or `270`",
 				Required:            true,
-				PlanModifiers: []planmodifier.Number{
-					numberplanmodifier.RequiresReplace(),
-				},
 			},
 			"schema": schema.StringAttribute{
-				MarkdownDescription: "Example configurable attribute",
-				Required:            true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
+				MarkdownDescription: "Path to a local schema zip file, exactly one of `schema`, `schema_base64` or `schema_url` must be set",
+				Optional:            true,
+			},
+			"schema_base64": schema.StringAttribute{
+				MarkdownDescription: "Base64 encoded content of a schema zip file, for example `filebase64(\"car.zip\")`",
+				Optional:            true,
+			},
+			"schema_url": schema.StringAttribute{
+				MarkdownDescription: "URL of a schema zip file, supported schemes are `http`, `https` and `file`",
+				Optional:            true,
+			},
+			"schema_sha256": schema.StringAttribute{
+				MarkdownDescription: "Hex encoded SHA-256 checksum the content at `schema_url` must match, when set the schema is not downloaded during plan and the bounding box is only known after apply",
+				Optional:            true,
 			},
 			"schema_hash": schema.StringAttribute{
-				MarkdownDescription: "Example configurable attribute",
+				MarkdownDescription: "Base64 encoded SHA-256 of the placed schema content, a change in the content at the source places the schema again",
 				Computed:            true,
 				PlanModifiers: []planmodifier.String{
 					&schemaPlanModifier{},
-					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"start_x": schema.NumberAttribute{
+				MarkdownDescription: "Start X coordinate of the placed structure's bounding box, computed from the schema during plan",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Number{
+					numberplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"start_y": schema.NumberAttribute{
+				MarkdownDescription: "Start Y coordinate of the placed structure's bounding box, computed from the schema during plan",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Number{
+					numberplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"start_z": schema.NumberAttribute{
+				MarkdownDescription: "Start Z coordinate of the placed structure's bounding box, computed from the schema during plan",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Number{
+					numberplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"end_x": schema.NumberAttribute{
+				MarkdownDescription: "End X coordinate of the placed structure's bounding box, computed from the schema during plan",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Number{
+					numberplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"end_y": schema.NumberAttribute{
+				MarkdownDescription: "End Y coordinate of the placed structure's bounding box, computed from the schema during plan",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Number{
+					numberplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"end_z": schema.NumberAttribute{
+				MarkdownDescription: "End Z coordinate of the placed structure's bounding box, computed from the schema during plan",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Number{
+					numberplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"world": schema.StringAttribute{
+				MarkdownDescription: "World the schema is placed in, e.g. `minecraft:the_nether`. Defaults to the provider `world`, changing it replaces the schema.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					worldValidator{},
 				},
 			},
 			"id": schema.StringAttribute{
 				Computed:            true,
-				MarkdownDescription: "Example identifier",
+				MarkdownDescription: "Undo identifier of the placement on the server, prefixed with the world for placements outside the overworld, e.g. `minecraft:the_nether/<id>`",
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
@@ -129,42 +188,79 @@ func (r *SchemaResource) Configure(ctx context.Context, req resource.ConfigureRe
 	r.minecraftClient = client
 }
 
-func (r *SchemaResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+func (r *SchemaResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
 	var data SchemaResourceModel
 
-	// Read Terraform plan data into the model
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	x, _ := data.X.ValueBigFloat().Int64()
-	y, _ := data.Y.ValueBigFloat().Int64()
-	z, _ := data.Z.ValueBigFloat().Int64()
-	rotation, _ := data.Rotation.ValueBigFloat().Int64()
+	if data.Schema.IsUnknown() || data.SchemaBase64.IsUnknown() || data.SchemaURL.IsUnknown() {
+		return
+	}
 
-	sr := schemaRequest{
-		X:        int(x),
-		Y:        int(y),
-		Z:        int(z),
-		Rotation: int(rotation),
-		Schema:   data.Schema.ValueString(),
+	set := 0
+	for _, v := range []types.String{data.Schema, data.SchemaBase64, data.SchemaURL} {
+		if !v.IsNull() {
+			set++
+		}
 	}
 
-	id, err := r.minecraftClient.createSchema(sr)
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create example, got error: %s", err))
+	if set != 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("schema"),
+			"Invalid Schema Source",
+			"Exactly one of 'schema', 'schema_base64' or 'schema_url' must be set",
+		)
+	}
+
+	if !data.SchemaSHA256.IsNull() && data.SchemaURL.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("schema_sha256"),
+			"Invalid Schema Checksum",
+			"'schema_sha256' can only be set with 'schema_url'",
+		)
+	}
+
+	if !data.Rotation.IsNull() && !data.Rotation.IsUnknown() {
+		if _, _, err := rotateOffset(0, 0, intValue(data.Rotation)); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("rotation"), "Invalid Rotation", err.Error())
+		}
+	}
+
+	if !data.SchemaSHA256.IsNull() && !data.SchemaSHA256.IsUnknown() {
+		if sum, err := hex.DecodeString(data.SchemaSHA256.ValueString()); err != nil || len(sum) != sha256.Size {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("schema_sha256"),
+				"Invalid Schema Checksum",
+				"'schema_sha256' must be a hex encoded SHA-256 checksum",
+			)
+		}
+	}
+}
+
+func (r *SchemaResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := telemetry.Start(ctx, "minecraft_schema.Create")
+	defer endOperation(span, &resp.Diagnostics)
+
+	var data SchemaResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	data.Id = types.StringValue(id)
+	data.World = r.minecraftClient.resolveWorld(data.World)
 
-	hash, err := calculateHashFromFile(data.Schema.ValueString())
+	err := r.place(ctx, &data)
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to generate hash for file", err.Error())
+		addClientError(&resp.Diagnostics, "create schema", err)
+		return
 	}
-	data.SchemaHash = types.StringValue(hash)
 
 	// Write logs using the tflog package
 	// Documentation: https://terraform.io/plugin/log
@@ -175,6 +271,9 @@ func (r *SchemaResource) Create(ctx context.Context, req resource.CreateRequest,
 }
 
 func (r *SchemaResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := telemetry.Start(ctx, "minecraft_schema.Read")
+	defer endOperation(span, &resp.Diagnostics)
+
 	var data SchemaResourceModel
 
 	// Read Terraform prior state data into the model
@@ -188,31 +287,131 @@ func (r *SchemaResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
-	_, err := r.minecraftClient.getSchemaDetails(data.Id.ValueString())
+	data.World = types.StringValue(worldValue(data.World))
+
+	details, err := r.minecraftClient.getSchemaDetails(ctx, data.World.ValueString(), data.undoID())
+	if IsNotFound(err) {
+		// the schema has been undone outside of Terraform, or the world has
+		// been reset, remove it from state so that it is placed again
+		tflog.Warn(ctx, "schema no longer exists, removing from state", map[string]interface{}{
+			"id": data.Id.ValueString(),
+		})
+
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read schema, got error: %s", err))
+		addClientError(&resp.Diagnostics, "read schema", err)
 		return
 	}
 
+	data.setBounds(details)
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// Update moves, rotates or replaces the schema in a single operation. When
+// the old and new placements do not overlap the new schema is placed before
+// the old one is undone, so that the structure always exists. Overlapping
+// placements must be undone first as undoing afterwards would restore the
+// original terrain over the new structure, if the new placement then fails
+// the old schema is placed again.
 func (r *SchemaResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data SchemaResourceModel
+	ctx, span := telemetry.Start(ctx, "minecraft_schema.Update")
+	defer endOperation(span, &resp.Diagnostics)
 
-	// Read Terraform plan data into the model
+	var data, state SchemaResourceModel
+
+	// Read Terraform plan and prior state data into the models
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// only the attribute the same content is read from has changed, the
+	// placement is kept
+	if data.samePlacement(state) {
+		data.Id = state.Id
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	if !state.overlaps(data) {
+		err := r.place(ctx, &data)
+		if err != nil {
+			addClientError(&resp.Diagnostics, "place updated schema", err)
+			return
+		}
+
+		err = r.minecraftClient.undoSchema(ctx, worldValue(state.World), state.undoID())
+		if err != nil && !IsNotFound(err) {
+			// roll back the new placement so that the world matches state
+			if rbErr := r.minecraftClient.undoSchema(ctx, worldValue(data.World), data.undoID()); rbErr != nil {
+				resp.Diagnostics.AddError(
+					"Rollback Failed",
+					fmt.Sprintf("Unable to undo the new placement %s after failing to undo the previous placement, both placements exist in the world: %s", data.Id.ValueString(), rbErr),
+				)
+			}
+
+			addClientError(&resp.Diagnostics, "undo previous schema", err)
+			return
+		}
+
+		tflog.Trace(ctx, "updated a resource")
+
+		// Save updated data into Terraform state
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	// keep the content of the previous placement for the rollback, the
+	// source may have changed since, which is often why it is updated
+	previous, previousErr := state.placedContent(ctx)
+
+	err := r.minecraftClient.undoSchema(ctx, worldValue(state.World), state.undoID())
+	if err != nil && !IsNotFound(err) {
+		addClientError(&resp.Diagnostics, "undo previous schema", err)
+		return
+	}
+
+	err = r.place(ctx, &data)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "place updated schema", err)
+
+		// roll back by placing the previous schema again
+		rbErr := previousErr
+		if rbErr == nil {
+			rbErr = r.placeContent(ctx, &state, previous)
+		}
+
+		if rbErr != nil {
+			resp.Diagnostics.AddError(
+				"Rollback Failed",
+				fmt.Sprintf("Unable to place the previous schema again after the update failed, the schema no longer exists in the world: %s", rbErr),
+			)
+
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		return
+	}
+
+	tflog.Trace(ctx, "updated a resource")
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *SchemaResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := telemetry.Start(ctx, "minecraft_schema.Delete")
+	defer endOperation(span, &resp.Diagnostics)
+
 	var data SchemaResourceModel
 
 	// Read Terraform prior state data into the model
@@ -222,37 +421,360 @@ func (r *SchemaResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	err := r.minecraftClient.undoSchema(data.Id.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete schema, got error: %s", err))
+	err := r.minecraftClient.undoSchema(ctx, worldValue(data.World), data.undoID())
+	if err != nil && !IsNotFound(err) {
+		addClientError(&resp.Diagnostics, "delete schema", err)
 		return
 	}
 }
 
+// ImportState imports a schema using its undo ID, or "world/undo_id" for a
+// schema outside the overworld.
 func (r *SchemaResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	world, undoID := splitWorldID(req.ID)
+
+	if world != "" {
+		if err := validateWorld(world); err != nil {
+			resp.Diagnostics.AddError(
+				"Unexpected Import Identifier",
+				fmt.Sprintf("Expected import identifier with format: undo_id or world/undo_id. Got: %q, error: %s", req.ID, err),
+			)
+			return
+		}
+	}
+
+	w := importWorld(world)
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("world"), w)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), worldID(w.ValueString(), undoID))...)
 }
 
-func calculateHashFromFile(path string) (string, error) {
-	// generate a hash of the file so that we can track changes
-	f, err := os.Open(path)
+// ModifyPlan reads the schema content and computes the region the structure
+// will occupy after rotation, so that the plan shows the exact bounding box.
+// Pinned URLs are not downloaded, their bounding box is known after apply.
+// When the placement changes the identifier is marked as unknown, as an
+// update creates a new placement.
+func (r *SchemaResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to do when the resource is being destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	planWorld(ctx, r.minecraftClient, req, resp)
+
+	var plan SchemaResourceModel
+
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	replaces := ""
+
+	if !req.State.Raw.IsNull() {
+		var state SchemaResourceModel
+
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if plan.samePlacement(state) {
+			r.checkOverlap(ctx, plan, state.Id.ValueString(), "", &resp.Diagnostics)
+			return
+		}
+
+		replaces = state.Id.ValueString()
+		plan.Id = types.StringUnknown()
+	}
+
+	plan.StartX = types.NumberUnknown()
+	plan.StartY = types.NumberUnknown()
+	plan.StartZ = types.NumberUnknown()
+	plan.EndX = types.NumberUnknown()
+	plan.EndY = types.NumberUnknown()
+	plan.EndZ = types.NumberUnknown()
+
+	if plan.known() {
+		resp.Diagnostics.Append(plan.previewBounds(ctx)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		r.checkOverlap(ctx, plan, "", replaces, &resp.Diagnostics)
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// checkOverlap reports the placements the bounding box in m intersects,
+// according to the provider schema_overlap setting. id is the undo ID when
+// the placement is unchanged and replaces the undo ID of the placement an
+// update replaces.
+func (r *SchemaResource) checkOverlap(ctx context.Context, m SchemaResourceModel, id, replaces string, diags *diag.Diagnostics) {
+	if r.minecraftClient == nil || m.StartX.IsNull() || m.StartX.IsUnknown() || m.World.IsUnknown() {
+		return
+	}
+
+	mode := r.minecraftClient.placements.mode
+	if mode == schemaOverlapIgnore {
+		return
+	}
+
+	p := schemaPlacement{
+		ID:    id,
+		Key:   m.placementKey(),
+		World: worldValue(m.World),
+		Start: coordinate{intValue(m.StartX), intValue(m.StartY), intValue(m.StartZ)},
+		End:   coordinate{intValue(m.EndX), intValue(m.EndY), intValue(m.EndZ)},
+	}
+
+	overlaps, err := r.minecraftClient.checkSchemaPlacement(ctx, p, replaces)
+	if err != nil {
+		diags.AddWarning(
+			"Unable to List Schemas",
+			fmt.Sprintf("Unable to list the schemas placed on the server, only schemas in this configuration are checked for overlaps: %s", err),
+		)
+	}
+
+	for _, o := range overlaps {
+		summary := "Schema Placement Overlap"
+		detail := fmt.Sprintf("The schema placed from %s to %s overlaps the %s, one of the structures will overwrite the other. Set the provider attribute 'schema_overlap' to \"warning\" or \"ignore\" to allow overlapping placements.", p.Start, p.End, o)
+
+		if mode == schemaOverlapWarning {
+			diags.AddAttributeWarning(path.Root("x"), summary, detail)
+			continue
+		}
+
+		diags.AddAttributeError(path.Root("x"), summary, detail)
+	}
+}
+
+// place creates the schema described by the model and records the new
+// identifier, hash and bounding box.
+func (r *SchemaResource) place(ctx context.Context, m *SchemaResourceModel) error {
+	data, err := m.source().read(ctx)
+	if err != nil {
+		return err
+	}
+
+	return r.placeContent(ctx, m, data)
+}
+
+// placeContent creates the schema with the zip content in data at the
+// placement described by the model. The model is only updated when the
+// placement succeeds, a placement whose details can not be read is undone.
+func (r *SchemaResource) placeContent(ctx context.Context, m *SchemaResourceModel, data []byte) error {
+	sr := schemaRequest{
+		X:        intValue(m.X),
+		Y:        intValue(m.Y),
+		Z:        intValue(m.Z),
+		Rotation: intValue(m.Rotation),
+		Schema:   data,
+	}
+
+	world := worldValue(m.World)
+
+	id, err := r.minecraftClient.createSchema(ctx, world, sr)
 	if err != nil {
-		return "", fmt.Errorf("unable to generate hash for schema file: %s", err)
+		return err
 	}
-	defer f.Close()
 
-	h := sha256.New()
-	if _, err := io.Copy(h, f); err != nil {
-		return "", fmt.Errorf("unable to generate hash for schema file: %s", err)
+	details, err := r.minecraftClient.getSchemaDetails(ctx, world, id)
+	if err != nil {
+		if undoErr := r.minecraftClient.undoSchema(ctx, world, id); undoErr != nil && !IsNotFound(undoErr) {
+			return fmt.Errorf("%w, undoing the placement %s also failed, it must be removed in game: %s", err, worldID(world, id), undoErr)
+		}
+
+		return err
 	}
 
-	return base64.StdEncoding.EncodeToString(h.Sum(nil)), nil
+	m.Id = types.StringValue(worldID(world, id))
+	m.SchemaHash = types.StringValue(calculateHash(data))
+	m.setBounds(details)
+
+	return nil
+}
+
+// overlaps returns true when the placement in m may overlap the placement in
+// other. The new footprint is only known when the same schema is placed with
+// the same rotation, in every other case an overlap is assumed.
+func (m SchemaResourceModel) overlaps(other SchemaResourceModel) bool {
+	if !m.sameSource(other) || !m.Rotation.Equal(other.Rotation) {
+		return true
+	}
+
+	if m.StartX.IsNull() || m.StartX.IsUnknown() {
+		return true
+	}
+
+	dx := intValue(other.X) - intValue(m.X)
+	dy := intValue(other.Y) - intValue(m.Y)
+	dz := intValue(other.Z) - intValue(m.Z)
+
+	start := coordinate{intValue(m.StartX), intValue(m.StartY), intValue(m.StartZ)}
+	end := coordinate{intValue(m.EndX), intValue(m.EndY), intValue(m.EndZ)}
+	moved := coordinate{start.X + dx, start.Y + dy, start.Z + dz}
+	movedEnd := coordinate{end.X + dx, end.Y + dy, end.Z + dz}
+
+	return boxesIntersect(start, end, moved, movedEnd)
+}
+
+// placedContent returns the content of the schema placed by the model in
+// state, it fails when the source no longer has the content that was placed.
+func (m SchemaResourceModel) placedContent(ctx context.Context) ([]byte, error) {
+	src := m.source()
+
+	data, err := src.read(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the previous schema %s: %s", src, err)
+	}
+
+	if h := calculateHash(data); h != m.SchemaHash.ValueString() {
+		return nil, fmt.Errorf("the previous schema %s has changed since it was placed, expected hash %s, got %s", src, m.SchemaHash.ValueString(), h)
+	}
+
+	return data, nil
+}
+
+// placementKey identifies a new placement by its configuration, Terraform
+// does not pass the resource address when planning.
+func (m SchemaResourceModel) placementKey() string {
+	src := m.source()
+	return fmt.Sprintf("%s/%d,%d,%d@%d:%s", worldValue(m.World), intValue(m.X), intValue(m.Y), intValue(m.Z), intValue(m.Rotation),
+		calculateHash([]byte(src.Path+"\x00"+src.Base64+"\x00"+src.URL)))
+}
+
+// undoID returns the identifier of the placement on the server.
+func (m SchemaResourceModel) undoID() string {
+	_, id := splitWorldID(m.Id.ValueString())
+	return id
+}
+
+// known returns true when the placement and schema source are known.
+func (m SchemaResourceModel) known() bool {
+	for _, v := range []types.Number{m.X, m.Y, m.Z, m.Rotation} {
+		if v.IsUnknown() {
+			return false
+		}
+	}
+
+	for _, v := range []types.String{m.Schema, m.SchemaBase64, m.SchemaURL, m.SchemaSHA256} {
+		if v.IsUnknown() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// previewBounds parses the schema content, validating the archive and its
+// materials, and sets the bounding box the structure will occupy. A schema_url
+// pinned by schema_sha256 is not downloaded and the bounding box is left
+// unknown.
+func (m *SchemaResourceModel) previewBounds(ctx context.Context) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !m.SchemaURL.IsNull() && !m.SchemaSHA256.IsNull() {
+		return diags
+	}
+
+	src := m.source()
+	attr := m.sourcePath()
+
+	data, err := src.read(ctx)
+	if err != nil {
+		diags.AddAttributeError(attr, "Unable to Read Schema", err.Error())
+		return diags
+	}
+
+	voxels, err := readSchemaZip(data)
+	if err != nil {
+		diags.AddAttributeError(attr, "Invalid Schema", fmt.Sprintf("The schema %s is not a valid schema zip: %s", src, err))
+		return diags
+	}
+
+	for _, v := range voxels {
+		if err := validateMaterial(v.Material); err != nil {
+			diags.AddAttributeError(
+				attr,
+				"Invalid Schema Material",
+				fmt.Sprintf("The block at %d,%d,%d in schema %s has an %s", v.X, v.Y, v.Z, src, err),
+			)
+		}
+	}
+
+	if diags.HasError() {
+		return diags
+	}
+
+	origin := coordinate{intValue(m.X), intValue(m.Y), intValue(m.Z)}
+
+	lo, hi, err := schemaBounds(voxels, origin, intValue(m.Rotation))
+	if err != nil {
+		diags.AddAttributeError(path.Root("rotation"), "Invalid Rotation", err.Error())
+		return diags
+	}
+
+	m.setBounds(&schemaDetailsResponse{lo.X, lo.Y, lo.Z, hi.X, hi.Y, hi.Z})
+
+	return diags
+}
+
+// source returns where the schema content is read from.
+func (m SchemaResourceModel) source() schemaSource {
+	return schemaSource{
+		Path:   m.Schema.ValueString(),
+		Base64: m.SchemaBase64.ValueString(),
+		URL:    m.SchemaURL.ValueString(),
+		SHA256: m.SchemaSHA256.ValueString(),
+	}
+}
+
+// sourcePath returns the path of the attribute the schema is read from.
+func (m SchemaResourceModel) sourcePath() path.Path {
+	switch {
+	case !m.SchemaBase64.IsNull():
+		return path.Root("schema_base64")
+	case !m.SchemaURL.IsNull():
+		return path.Root("schema_url")
+	}
+
+	return path.Root("schema")
+}
+
+// sameSource returns true when m and other place the same schema content.
+// The content is compared by hash, so moving the same schema between
+// schema, schema_base64 and schema_url does not place it again.
+func (m SchemaResourceModel) sameSource(other SchemaResourceModel) bool {
+	return !m.SchemaHash.IsNull() && !m.SchemaHash.IsUnknown() && m.SchemaHash.Equal(other.SchemaHash)
+}
+
+// samePlacement returns true when m and other place the same schema content
+// at the same position, rotation and world.
+func (m SchemaResourceModel) samePlacement(other SchemaResourceModel) bool {
+	return m.X.Equal(other.X) && m.Y.Equal(other.Y) && m.Z.Equal(other.Z) &&
+		m.Rotation.Equal(other.Rotation) && !m.World.IsUnknown() && worldValue(m.World) == worldValue(other.World) &&
+		m.sameSource(other)
+}
+
+// setBounds copies the bounding box of the placed schema into the model.
+func (m *SchemaResourceModel) setBounds(d *schemaDetailsResponse) {
+	m.StartX = numberValue(d.StartX)
+	m.StartY = numberValue(d.StartY)
+	m.StartZ = numberValue(d.StartZ)
+	m.EndX = numberValue(d.EndX)
+	m.EndY = numberValue(d.EndY)
+	m.EndZ = numberValue(d.EndZ)
 }
 
 type schemaPlanModifier struct{}
 
 func (s schemaPlanModifier) Description(ctx context.Context) string {
-	return "checks if the file represented by schema has changed"
+	return "checks if the content of the schema source has changed"
 }
 
 func (s schemaPlanModifier) MarkdownDescription(ctx context.Context) string {
@@ -260,29 +782,46 @@ func (s schemaPlanModifier) MarkdownDescription(ctx context.Context) string {
 }
 
 func (s schemaPlanModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
-	// generate a hash from the file
-	attrPath := path.Empty().AtName("schema")
-	schema := ""
-	req.Plan.GetAttribute(ctx, attrPath, &schema)
+	// if there is no state this is the first apply, the hash is set on create
+	if req.StateValue.IsNull() {
+		return
+	}
 
-	attrPath = path.Empty().AtName("schema_hash")
-	schemaHash := ""
-	req.Plan.GetAttribute(ctx, attrPath, &schemaHash)
+	var data SchemaResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 
-	// if empty probably first apply
-	if schemaHash == "" {
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// the content can not be read until the source is known
+	if data.Schema.IsUnknown() || data.SchemaBase64.IsUnknown() || data.SchemaURL.IsUnknown() || data.SchemaSHA256.IsUnknown() {
+		resp.PlanValue = types.StringUnknown()
+		return
+	}
+
+	src := data.source()
+	schemaHash := req.StateValue.ValueString()
+
 	// caclculate and compare the hash
-	newHash, _ := calculateHashFromFile(schema)
+	newHash, err := src.hash(ctx)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Unable to Read Schema",
+			fmt.Sprintf("Unable to generate hash for schema %s: %s", src, err),
+		)
+
+		return
+	}
+
+	resp.PlanValue = types.StringValue(newHash)
+
 	if newHash != schemaHash {
-		// set the new value and set the requires replace, Terraform will force the resource to be re-created
-		resp.PlanValue = types.StringValue(newHash)
-		resp.RequiresReplace = true
+		// Terraform will update the resource placing the new schema
 		resp.Diagnostics.AddWarning(
 			"Schema File Changed",
-			fmt.Sprintf("The file %s has changed from when the resource was originally created, this forces the destruction of the resource. Old file hash: %s, New file hash: %s", schema, schemaHash, newHash),
+			fmt.Sprintf("The schema %s has changed from when the resource was originally created, the schema will be placed again. Old file hash: %s, New file hash: %s", src, schemaHash, newHash),
 		)
 	}
 }
@@ -1,13 +1,28 @@
 package provider
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync/atomic"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 func TestAccSchemaResource(t *testing.T) {
+	var id string
+
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
@@ -19,12 +34,245 @@ func TestAccSchemaResource(t *testing.T) {
 					resource.TestCheckResourceAttr("minecraft_schema.car", "x", "1"),
 					resource.TestCheckResourceAttr("minecraft_schema.car", "y", "2"),
 					resource.TestCheckResourceAttr("minecraft_schema.car", "z", "3"),
+					resource.TestCheckResourceAttrSet("minecraft_schema.car", "start_x"),
+					resource.TestCheckResourceAttrSet("minecraft_schema.car", "end_z"),
+				),
+			},
+			// Update testing, moving one block overlaps the previous placement
+			{
+				Config: testAccSchemaResourceConfig(1, 2, 4),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("minecraft_schema.car", "z", "4"),
+					resource.TestCheckResourceAttrSet("minecraft_schema.car", "id"),
+				),
+			},
+			// Update testing, moving far away places the new schema first
+			{
+				Config: testAccSchemaResourceConfig(100, 2, 4),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("minecraft_schema.car", "x", "100"),
+					testAccSaveAttr("minecraft_schema.car", "id", &id),
+				),
+			},
+			// Update testing, switching to inline content of the same file
+			// keeps the hash and the placement
+			{
+				Config: testAccSchemaResourceBase64Config(t, 100, 2, 4),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("minecraft_schema.car", "schema"),
+					resource.TestCheckResourceAttrSet("minecraft_schema.car", "schema_base64"),
+					resource.TestCheckResourceAttrSet("minecraft_schema.car", "schema_hash"),
+					resource.TestCheckResourceAttrPtr("minecraft_schema.car", "id", &id),
 				),
 			},
 		},
 	})
 }
 
+func TestAccSchemaResourceInvalidZip(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+  resource "minecraft_schema" "car" {
+	  x = 0
+	  y = 0
+	  z = 0
+	  rotation = 0
+	  schema_base64 = base64encode("not a zip")
+	}
+  `,
+				ExpectError: regexp.MustCompile("Invalid Schema"),
+			},
+		},
+	})
+}
+
+func TestSchemaResourceModelOverlaps(t *testing.T) {
+	state := SchemaResourceModel{
+		X:          numberValue(0),
+		Y:          numberValue(0),
+		Z:          numberValue(0),
+		Rotation:   numberValue(90),
+		Schema:     types.StringValue("car.zip"),
+		SchemaHash: types.StringValue("abc"),
+		StartX:     numberValue(-4),
+		StartY:     numberValue(0),
+		StartZ:     numberValue(0),
+		EndX:       numberValue(0),
+		EndY:       numberValue(3),
+		EndZ:       numberValue(10),
+	}
+
+	moved := state
+	moved.X = numberValue(2)
+
+	if !state.overlaps(moved) {
+		t.Fatal("expected a small move to overlap")
+	}
+
+	moved.X = numberValue(5)
+	if state.overlaps(moved) {
+		t.Fatal("expected a move larger than the footprint not to overlap")
+	}
+
+	moved.Rotation = numberValue(180)
+	if !state.overlaps(moved) {
+		t.Fatal("expected a rotation to be treated as an overlap")
+	}
+}
+
+func TestSchemaResourceModelSamePlacement(t *testing.T) {
+	hash := types.StringValue(calculateHash([]byte("car")))
+
+	state := SchemaResourceModel{
+		X:          numberValue(1),
+		Y:          numberValue(2),
+		Z:          numberValue(3),
+		Rotation:   numberValue(0),
+		Schema:     types.StringValue("car.zip"),
+		SchemaHash: hash,
+	}
+
+	// the same content inlined is the same placement
+	plan := state
+	plan.Schema = types.StringNull()
+	plan.SchemaBase64 = types.StringValue(base64.StdEncoding.EncodeToString([]byte("car")))
+
+	if !plan.samePlacement(state) {
+		t.Fatal("expected the same content from another source to be the same placement")
+	}
+
+	plan.SchemaHash = types.StringValue(calculateHash([]byte("truck")))
+	if plan.samePlacement(state) {
+		t.Fatal("expected different content to be a new placement")
+	}
+
+	plan.SchemaHash = types.StringUnknown()
+	if plan.samePlacement(state) {
+		t.Fatal("expected unknown content to be a new placement")
+	}
+
+	plan.SchemaHash = hash
+	plan.World = types.StringValue("minecraft:the_nether")
+	if plan.samePlacement(state) {
+		t.Fatal("expected another world to be a new placement")
+	}
+}
+
+func TestSchemaResourceModelPreviewBounds(t *testing.T) {
+	car, err := os.ReadFile("../../schemas/car.zip")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var downloads int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&downloads, 1)
+		w.Write(car)
+	}))
+	defer srv.Close()
+
+	m := SchemaResourceModel{
+		X:         numberValue(0),
+		Y:         numberValue(0),
+		Z:         numberValue(0),
+		Rotation:  numberValue(0),
+		SchemaURL: types.StringValue(srv.URL + "/car.zip"),
+		StartX:    types.NumberUnknown(),
+	}
+
+	if diags := m.previewBounds(context.Background()); diags.HasError() || m.StartX.IsUnknown() || downloads != 1 {
+		t.Fatalf("expected the bounds to be computed from the download, got %v, %d downloads", diags, downloads)
+	}
+
+	// a pinned URL is not downloaded during plan
+	sum := sha256.Sum256(car)
+	m.SchemaSHA256 = types.StringValue(hex.EncodeToString(sum[:]))
+	m.StartX = types.NumberUnknown()
+
+	if diags := m.previewBounds(context.Background()); diags.HasError() || !m.StartX.IsUnknown() || downloads != 1 {
+		t.Fatalf("expected the bounds to be unknown without downloading, got %v, %d downloads", diags, downloads)
+	}
+}
+
+func TestSchemaResourceModelPlacedContent(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "house.zip")
+	if err := os.WriteFile(file, []byte("placed"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	state := SchemaResourceModel{
+		Schema:     types.StringValue(file),
+		SchemaHash: types.StringValue(calculateHash([]byte("placed"))),
+	}
+
+	data, err := state.placedContent(context.Background())
+	if err != nil || string(data) != "placed" {
+		t.Fatalf("expected the placed content, got %q, %v", data, err)
+	}
+
+	// the file changed, which triggered the update, so the content that was
+	// placed is no longer available for a rollback
+	if err := os.WriteFile(file, []byte("updated"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := state.placedContent(context.Background()); err == nil || !strings.Contains(err.Error(), "has changed since it was placed") {
+		t.Fatalf("expected an error for changed content, got: %v", err)
+	}
+}
+
+func TestSchemaResourcePlaceContentUndo(t *testing.T) {
+	srv := newFakeMinecraftServer(t)
+	r := &SchemaResource{minecraftClient: newClient(srv.URL, fakeAPIKey, testClientOptions())}
+
+	car, err := os.ReadFile("../../schemas/car.zip")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// reading the details of the new placement fails on every retry
+	srv.failNext(http.MethodGet, "/v1/schema/details", http.StatusInternalServerError, 10)
+
+	m := SchemaResourceModel{
+		X:        numberValue(0),
+		Y:        numberValue(0),
+		Z:        numberValue(0),
+		Rotation: numberValue(0),
+		World:    types.StringValue(defaultWorld),
+		Id:       types.StringValue("undo-previous"),
+	}
+
+	if err := r.placeContent(context.Background(), &m, car); !hasStatus(err, http.StatusInternalServerError) {
+		t.Fatalf("expected the details error, got: %v", err)
+	}
+
+	if m.Id.ValueString() != "undo-previous" {
+		t.Fatalf("expected the identifier to be unchanged, got %s", m.Id.ValueString())
+	}
+
+	if n := srv.requestCount(http.MethodDelete, "/v1/schema/undo/undo-1"); n != 1 {
+		t.Fatalf("expected the placement to be undone, got %d requests", n)
+	}
+}
+
+// testAccSaveAttr stores the value of an attribute so that a later step can
+// check it has not changed.
+func testAccSaveAttr(name, key string, v *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s not found", name)
+		}
+
+		*v = rs.Primary.Attributes[key]
+		return nil
+	}
+}
+
 func testAccSchemaResourceConfig(x, y, z int) string {
 	return fmt.Sprintf(`
   resource "minecraft_schema" "car" {
@@ -36,3 +284,22 @@ func testAccSchemaResourceConfig(x, y, z int) string {
 	}
   `, x, y, z)
 }
+
+// testAccSchemaResourceBase64Config inlines the content of car.zip, filebase64
+// can not be used as Terraform runs in a temporary working directory.
+func testAccSchemaResourceBase64Config(t *testing.T, x, y, z int) string {
+	car, err := os.ReadFile("../../schemas/car.zip")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return fmt.Sprintf(`
+  resource "minecraft_schema" "car" {
+	  x = %d
+	  y = %d
+	  z = %d
+	  rotation = 270
+	  schema_base64 = %q
+	}
+  `, x, y, z, base64.StdEncoding.EncodeToString(car))
+}
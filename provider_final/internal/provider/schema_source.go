@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// schemaDownloadTimeout limits how long fetching a schema_url may take.
+const schemaDownloadTimeout = 2 * time.Minute
+
+// schemaSource describes where the zip content of a schema comes from,
+// exactly one of Path, Base64 or URL is set.
+type schemaSource struct {
+	Path   string
+	Base64 string
+	URL    string
+	// SHA256 is the expected hex encoded SHA-256 of the content at URL.
+	SHA256 string
+}
+
+func (s schemaSource) String() string {
+	switch {
+	case s.Base64 != "":
+		return "schema_base64"
+	case s.URL != "":
+		return s.URL
+	}
+
+	return s.Path
+}
+
+// read returns the zip content of the schema, content fetched from a URL is
+// verified against the pinned checksum when one is set.
+func (s schemaSource) read(ctx context.Context) ([]byte, error) {
+	var data []byte
+	var err error
+
+	switch {
+	case s.Base64 != "":
+		data, err = base64.StdEncoding.DecodeString(s.Base64)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode schema_base64: %s", err)
+		}
+	case s.URL != "":
+		data, err = fetchSchema(ctx, s.URL)
+		if err != nil {
+			return nil, err
+		}
+
+		if s.SHA256 != "" {
+			sum := sha256.Sum256(data)
+			if got := hex.EncodeToString(sum[:]); !strings.EqualFold(got, s.SHA256) {
+				return nil, fmt.Errorf("checksum mismatch for %s, expected sha256 %s, got %s", s.URL, s.SHA256, got)
+			}
+		}
+	default:
+		data, err = os.ReadFile(s.Path)
+		if err != nil {
+			return nil, fmt.Errorf("unable to open schema file: %s, err: %s", s.Path, err)
+		}
+	}
+
+	return data, nil
+}
+
+// hash returns the base64 encoded SHA-256 of the schema content, the same
+// value stored in schema_hash. When a URL is pinned
+// to a checksum the hash is derived from the checksum without downloading.
+func (s schemaSource) hash(ctx context.Context) (string, error) {
+	if s.URL != "" && s.SHA256 != "" {
+		sum, err := hex.DecodeString(s.SHA256)
+		if err != nil || len(sum) != sha256.Size {
+			return "", fmt.Errorf("invalid schema_sha256 %q, must be a hex encoded SHA-256", s.SHA256)
+		}
+
+		return base64.StdEncoding.EncodeToString(sum), nil
+	}
+
+	data, err := s.read(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return calculateHash(data), nil
+}
+
+// fetchSchema downloads a schema from a http, https or file URL.
+func fetchSchema(ctx context.Context, rawURL string) ([]byte, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema_url %q: %s", rawURL, err)
+	}
+
+	switch u.Scheme {
+	case "file":
+		data, err := os.ReadFile(u.Path)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read schema from %s: %s", rawURL, err)
+		}
+
+		return data, nil
+	case "http", "https":
+	default:
+		return nil, fmt.Errorf("unsupported scheme %q for schema_url, must be one of http, https or file", u.Scheme)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, schemaDownloadTimeout)
+	defer cancel()
+
+	r, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create request: %s", err)
+	}
+
+	resp, err := http.DefaultClient.Do(r)
+	if err != nil {
+		return nil, fmt.Errorf("unable to download schema from %s: %s", rawURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to download schema from %s, got status: %d", rawURL, resp.StatusCode)
+	}
+
+	buf := &bytes.Buffer{}
+	if _, err := io.Copy(buf, resp.Body); err != nil {
+		return nil, fmt.Errorf("unable to download schema from %s: %s", rawURL, err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// calculateHash returns the base64 encoded SHA-256 of data.
+func calculateHash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestSchemaSourceRead(t *testing.T) {
+	car, err := os.ReadFile("../../schemas/car.zip")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sum := sha256.Sum256(car)
+	pin := hex.EncodeToString(sum[:])
+
+	downloads := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		downloads++
+		w.Write(car)
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	want := calculateHash(car)
+
+	sources := []schemaSource{
+		{Path: "../../schemas/car.zip"},
+		{Base64: base64.StdEncoding.EncodeToString(car)},
+		{URL: srv.URL + "/car.zip"},
+		{URL: srv.URL + "/car.zip", SHA256: strings.ToUpper(pin)},
+	}
+
+	for _, src := range sources {
+		data, err := src.read(ctx)
+		if err != nil {
+			t.Fatalf("%s: %s", src, err)
+		}
+
+		if got := calculateHash(data); got != want {
+			t.Fatalf("%s: expected hash %s, got %s", src, want, got)
+		}
+	}
+
+	downloads = 0
+
+	got, err := schemaSource{URL: srv.URL + "/car.zip", SHA256: pin}.hash(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != want {
+		t.Fatalf("expected hash %s, got %s", want, got)
+	}
+
+	if downloads != 0 {
+		t.Fatalf("expected the pinned hash not to download the schema, got %d downloads", downloads)
+	}
+
+	_, err = schemaSource{URL: srv.URL + "/car.zip", SHA256: strings.Repeat("0", 64)}.read(ctx)
+	if err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Fatalf("expected checksum mismatch, got %v", err)
+	}
+
+	_, err = schemaSource{URL: "ftp://example.com/car.zip"}.read(ctx)
+	if err == nil {
+		t.Fatal("expected an error for an unsupported scheme")
+	}
+}
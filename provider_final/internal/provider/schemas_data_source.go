@@ -0,0 +1,147 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/telemetry"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &SchemasDataSource{}
+
+func NewSchemasDataSource() datasource.DataSource {
+	return &SchemasDataSource{}
+}
+
+// SchemasDataSource defines the data source implementation.
+type SchemasDataSource struct {
+	minecraftClient *client
+}
+
+// SchemasDataSourceModel describes the data source data model.
+type SchemasDataSourceModel struct {
+	Schemas types.List   `tfsdk:"schemas"`
+	World   types.String `tfsdk:"world"`
+	Id      types.String `tfsdk:"id"`
+}
+
+func (d *SchemasDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_schemas"
+}
+
+func (d *SchemasDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := schemaPlacementAttributes()
+	attributes["id"] = schema.StringAttribute{
+		MarkdownDescription: "Undo identifier of the placed schema",
+		Computed:            true,
+	}
+
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Lists every schema placement the server knows about in a world. Requires a server that supports listing schemas with `GET /v1/schema`.",
+
+		Attributes: map[string]schema.Attribute{
+			"schemas": schema.ListNestedAttribute{
+				MarkdownDescription: "Placed schemas ordered by identifier",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: attributes,
+				},
+			},
+			"world": schema.StringAttribute{
+				MarkdownDescription: "World to list the placements of, e.g. `minecraft:the_nether`. Defaults to the provider `world`.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					worldValidator{},
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Data source identifier",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *SchemasDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	minecraftClient, ok := req.ProviderData.(*client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.minecraftClient = minecraftClient
+}
+
+func (d *SchemasDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := telemetry.Start(ctx, "data.minecraft_schemas.Read")
+	defer endOperation(span, &resp.Diagnostics)
+
+	var data SchemasDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.World = d.minecraftClient.resolveWorld(data.World)
+	world := data.World.ValueString()
+
+	items, err := d.minecraftClient.listSchemas(ctx, world)
+	switch {
+	case IsNotFound(err) || hasStatus(err, http.StatusMethodNotAllowed):
+		resp.Diagnostics.AddError(
+			"Listing Schemas Unsupported",
+			fmt.Sprintf("The Minecraft server does not support listing schemas, the minecraft_schemas data source requires the GET /v1/schema endpoint.\n\n%s", err),
+		)
+
+		return
+	case err != nil:
+		addClientError(&resp.Diagnostics, "list schemas", err)
+		return
+	}
+
+	sort.Slice(items, func(i, j int) bool { return items[i].ID < items[j].ID })
+
+	placements := make([]SchemaPlacementModel, 0, len(items))
+	for _, i := range items {
+		placements = append(placements, newSchemaPlacementModel(worldID(world, i.ID), world, &i.schemaDetailsResponse))
+	}
+
+	list, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: schemaPlacementAttrTypes}, placements)
+	resp.Diagnostics.Append(diags...)
+
+	data.Schemas = list
+	data.Id = types.StringValue(worldID(world, "schemas"))
+
+	tflog.Trace(ctx, "read a data source", map[string]interface{}{
+		"schemas": len(items),
+	})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,801 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math/big"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/numberdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/telemetry"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ShapeResource{}
+var _ resource.ResourceWithValidateConfig = &ShapeResource{}
+var _ resource.ResourceWithModifyPlan = &ShapeResource{}
+
+func NewShapeResource() resource.Resource {
+	return &ShapeResource{}
+}
+
+// ShapeResource defines the resource implementation.
+type ShapeResource struct {
+	minecraftClient *client
+}
+
+// ShapeResourceModel describes the resource data model.
+type ShapeResourceModel struct {
+	X            types.Number `tfsdk:"x"`
+	Y            types.Number `tfsdk:"y"`
+	Z            types.Number `tfsdk:"z"`
+	Rotation     types.Number `tfsdk:"rotation"`
+	ShapeFile    types.String `tfsdk:"shape_file"`
+	Blocks       types.List   `tfsdk:"blocks"`
+	SkipAir      types.Bool   `tfsdk:"skip_air"`
+	ShapeHash    types.String `tfsdk:"shape_hash"`
+	PlacedBlocks types.List   `tfsdk:"placed_blocks"`
+	World        types.String `tfsdk:"world"`
+	Id           types.String `tfsdk:"id"`
+}
+
+// ShapeVoxelModel describes a single block of an inline shape.
+type ShapeVoxelModel struct {
+	X        types.Number `tfsdk:"x"`
+	Y        types.Number `tfsdk:"y"`
+	Z        types.Number `tfsdk:"z"`
+	Material types.String `tfsdk:"material"`
+	Facing   types.String `tfsdk:"facing"`
+	Half     types.String `tfsdk:"half"`
+}
+
+// ShapeBlockModel describes a single block placed by the shape.
+type ShapeBlockModel struct {
+	X                types.Number `tfsdk:"x"`
+	Y                types.Number `tfsdk:"y"`
+	Z                types.Number `tfsdk:"z"`
+	Material         types.String `tfsdk:"material"`
+	State            types.Map    `tfsdk:"state"`
+	PreviousMaterial types.String `tfsdk:"previous_material"`
+	PreviousState    types.Map    `tfsdk:"previous_state"`
+}
+
+var shapeBlockAttrTypes = map[string]attr.Type{
+	"x":                 types.NumberType,
+	"y":                 types.NumberType,
+	"z":                 types.NumberType,
+	"material":          types.StringType,
+	"state":             types.MapType{ElemType: types.StringType},
+	"previous_material": types.StringType,
+	"previous_state":    types.MapType{ElemType: types.StringType},
+}
+
+// shapeBlock is the internal representation of a block placed by the shape.
+type shapeBlock struct {
+	material         string
+	state            map[string]string
+	previousMaterial string
+	previousState    map[string]string
+}
+
+func (r *ShapeResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_shape"
+}
+
+func (r *ShapeResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Places a list of voxels, such as `jumppad/shape.json`, only the blocks that change are sent on update and the original terrain is restored on destroy",
+
+		Attributes: map[string]schema.Attribute{
+			"x": schema.NumberAttribute{
+				MarkdownDescription: "X coordinate of the origin of the shape",
+				Required:            true,
+			},
+			"y": schema.NumberAttribute{
+				MarkdownDescription: "Y coordinate of the origin of the shape",
+				Required:            true,
+			},
+			"z": schema.NumberAttribute{
+				MarkdownDescription: "Z coordinate of the origin of the shape",
+				Required:            true,
+			},
+			"rotation": schema.NumberAttribute{
+				MarkdownDescription: "Clockwise rotation of the shape around the origin, one of `0`, `90`, `180` or `270`. Defaults to `0`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             numberdefault.StaticBigFloat(big.NewFloat(0)),
+			},
+			"shape_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a JSON file containing a list of `{x, y, z, material, facing, half}` objects, conflicts with `blocks`",
+				Optional:            true,
+			},
+			"blocks": schema.ListNestedAttribute{
+				MarkdownDescription: "Inline list of blocks relative to the origin, conflicts with `shape_file`",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"x": schema.NumberAttribute{
+							MarkdownDescription: "X offset of the block",
+							Required:            true,
+						},
+						"y": schema.NumberAttribute{
+							MarkdownDescription: "Y offset of the block",
+							Required:            true,
+						},
+						"z": schema.NumberAttribute{
+							MarkdownDescription: "Z offset of the block",
+							Required:            true,
+						},
+						"material": schema.StringAttribute{
+							MarkdownDescription: "Material of the block, e.g. `minecraft:stone`",
+							Required:            true,
+							Validators: []validator.String{
+								materialValidator{},
+							},
+						},
+						"facing": schema.StringAttribute{
+							MarkdownDescription: "Direction the block faces",
+							Optional:            true,
+						},
+						"half": schema.StringAttribute{
+							MarkdownDescription: "Half of the block for stairs, slabs and doors",
+							Optional:            true,
+						},
+					},
+				},
+			},
+			"skip_air": schema.BoolAttribute{
+				MarkdownDescription: "Do not place `minecraft:air` voxels, leaving the existing terrain in place. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"shape_hash": schema.StringAttribute{
+				MarkdownDescription: "Hash of the voxels in the shape, used to detect changes to `shape_file`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"placed_blocks": schema.ListNestedAttribute{
+				MarkdownDescription: "Blocks placed by the shape and the blocks they replaced",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"x": schema.NumberAttribute{
+							MarkdownDescription: "X coordinate of the block",
+							Computed:            true,
+						},
+						"y": schema.NumberAttribute{
+							MarkdownDescription: "Y coordinate of the block",
+							Computed:            true,
+						},
+						"z": schema.NumberAttribute{
+							MarkdownDescription: "Z coordinate of the block",
+							Computed:            true,
+						},
+						"material": schema.StringAttribute{
+							MarkdownDescription: "Material placed by the shape",
+							Computed:            true,
+						},
+						"state": schema.MapAttribute{
+							MarkdownDescription: "Block state properties placed by the shape",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+						"previous_material": schema.StringAttribute{
+							MarkdownDescription: "Material of the block before the shape was placed, restored on destroy",
+							Computed:            true,
+						},
+						"previous_state": schema.MapAttribute{
+							MarkdownDescription: "Block state properties before the shape was placed, restored on destroy",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"world": schema.StringAttribute{
+				MarkdownDescription: "World the shape is placed in, e.g. `minecraft:the_nether`. Defaults to the provider `world`, changing it replaces the shape.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					worldValidator{},
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Shape identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ShapeResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ShapeResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.ShapeFile.IsUnknown() || data.Blocks.IsUnknown() {
+		return
+	}
+
+	if data.ShapeFile.IsNull() == data.Blocks.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("shape_file"),
+			"Invalid Shape Source",
+			"Exactly one of 'shape_file' or 'blocks' must be set",
+		)
+	}
+
+	if !data.Rotation.IsNull() && !data.Rotation.IsUnknown() {
+		if _, _, err := rotateOffset(0, 0, intValue(data.Rotation)); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("rotation"), "Invalid Rotation", err.Error())
+		}
+	}
+}
+
+// ModifyPlan computes the hash of the voxels so that changes to the contents
+// of shape_file are planned as an update. The placed blocks are compared with
+// the blocks of the shape, so that blocks Read has found changed in game are
+// placed again, and the identifier follows the origin when it is moved.
+func (r *ShapeResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to do when the resource is being destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	planWorld(ctx, r.minecraftClient, req, resp)
+
+	var plan ShapeResourceModel
+
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	voxels, known, diags := plan.voxels(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ShapeHash = types.StringUnknown()
+	if known {
+		plan.ShapeHash = types.StringValue(hashVoxels(voxels, plan.SkipAir.ValueBool()))
+	}
+
+	plan.Id = types.StringUnknown()
+	if plan.originKnown() && !plan.World.IsUnknown() {
+		plan.Id = types.StringValue(plan.id())
+	}
+
+	if !req.State.Raw.IsNull() {
+		var state ShapeResourceModel
+
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		placed, diags := state.placedBlocks(ctx)
+		resp.Diagnostics.Append(diags...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		// the placed blocks will change when the shape changes, or when
+		// blocks no longer match the shape
+		if !known || !plan.originKnown() || plan.SkipAir.IsUnknown() || !plan.ShapeHash.Equal(state.ShapeHash) {
+			plan.PlacedBlocks = types.ListUnknown(types.ObjectType{AttrTypes: shapeBlockAttrTypes})
+		} else if desired, err := plan.worldBlocks(voxels); err != nil || !matchesShape(placed, desired) {
+			plan.PlacedBlocks = types.ListUnknown(types.ObjectType{AttrTypes: shapeBlockAttrTypes})
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// matchesShape returns true when the placed blocks are exactly the blocks
+// desired by the shape.
+func matchesShape(placed, desired map[coordinate]shapeBlock) bool {
+	if len(placed) != len(desired) {
+		return false
+	}
+
+	for c, want := range desired {
+		got, ok := placed[c]
+		if !ok || got.material != want.material || !maps.Equal(got.state, want.state) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r *ShapeResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.minecraftClient = client
+}
+
+func (r *ShapeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := telemetry.Start(ctx, "minecraft_shape.Create")
+	defer endOperation(span, &resp.Diagnostics)
+
+	var data ShapeResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.World = r.minecraftClient.resolveWorld(data.World)
+	data.Id = types.StringValue(data.id())
+	r.place(ctx, &data, map[coordinate]shapeBlock{}, &resp.Diagnostics)
+
+	tflog.Trace(ctx, "placed a shape")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ShapeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := telemetry.Start(ctx, "minecraft_shape.Read")
+	defer endOperation(span, &resp.Diagnostics)
+
+	var data ShapeResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	placed, diags := data.placedBlocks(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.World = types.StringValue(worldValue(data.World))
+
+	// check a sample of the placed blocks, any block that has been changed
+	// in game is recorded so that the next update places it again
+	drifted := false
+	for _, c := range sampleCoordinates(placed, fillSampleSize) {
+		block, err := r.minecraftClient.getBlock(ctx, data.World.ValueString(), c.X, c.Y, c.Z)
+		if err != nil {
+			addClientError(&resp.Diagnostics, "read block", err)
+			return
+		}
+
+		b := placed[c]
+		if block.Material != b.material {
+			tflog.Debug(ctx, "shape has drifted", map[string]interface{}{
+				"coordinate": c.String(),
+				"expected":   b.material,
+				"actual":     block.Material,
+			})
+
+			b.material = block.Material
+			b.state = block.blockState()
+			placed[c] = b
+			drifted = true
+		}
+	}
+
+	// ModifyPlan plans an update when the placed blocks no longer match
+	if drifted {
+		resp.Diagnostics.Append(data.setPlacedBlocks(ctx, placed)...)
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ShapeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := telemetry.Start(ctx, "minecraft_shape.Update")
+	defer endOperation(span, &resp.Diagnostics)
+
+	var data, state ShapeResourceModel
+
+	// Read Terraform plan and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	placed, diags := state.placedBlocks(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the identifier names the origin, which can be moved in place
+	data.World = types.StringValue(worldValue(data.World))
+	data.Id = types.StringValue(data.id())
+	r.place(ctx, &data, placed, &resp.Diagnostics)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ShapeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := telemetry.Start(ctx, "minecraft_shape.Delete")
+	defer endOperation(span, &resp.Diagnostics)
+
+	var data ShapeResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	placed, diags := data.placedBlocks(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	coords := sortedCoordinates(placed)
+	for _, err := range r.restore(ctx, worldValue(data.World), coords, placed) {
+		if err != nil {
+			addClientError(&resp.Diagnostics, "restore block", err)
+			return
+		}
+	}
+}
+
+// place diffs the voxels in data against the blocks that are already placed,
+// only blocks that differ are sent to the server and blocks that are no
+// longer part of the shape are restored. The blocks are sent in batches, the
+// placed blocks and hash are always written to data so that a partial apply
+// can be recovered.
+func (r *ShapeResource) place(ctx context.Context, data *ShapeResourceModel, placed map[coordinate]shapeBlock, diags *diag.Diagnostics) {
+	defer func() {
+		diags.Append(data.setPlacedBlocks(ctx, placed)...)
+	}()
+
+	voxels, _, d := data.voxels(ctx)
+	diags.Append(d...)
+
+	if diags.HasError() {
+		return
+	}
+
+	data.ShapeHash = types.StringValue(hashVoxels(voxels, data.SkipAir.ValueBool()))
+
+	desired, err := data.worldBlocks(voxels)
+	if err != nil {
+		diags.AddError("Invalid Shape", err.Error())
+		return
+	}
+
+	world := worldValue(data.World)
+	sent := 0
+
+	var pending []coordinate
+	var requests []blockRequest
+	blocks := map[coordinate]shapeBlock{}
+
+	for _, c := range sortedCoordinates(desired) {
+		want := desired[c]
+
+		current, tracked := placed[c]
+		if !tracked {
+			block, err := r.minecraftClient.getBlock(ctx, world, c.X, c.Y, c.Z)
+			if err != nil {
+				addClientError(diags, "read block", err)
+				return
+			}
+
+			current = shapeBlock{
+				material:         block.Material,
+				state:            block.blockState(),
+				previousMaterial: block.Material,
+				previousState:    block.blockState(),
+			}
+		}
+
+		changed := current.material != want.material || !maps.Equal(current.state, want.state)
+
+		current.material = want.material
+		current.state = want.state
+
+		if changed {
+			br := blockRequest{X: c.X, Y: c.Y, Z: c.Z, Material: want.material}
+			br.setState(want.state)
+
+			pending = append(pending, c)
+			requests = append(requests, br)
+			blocks[c] = current
+
+			continue
+		}
+
+		placed[c] = current
+	}
+
+	var placeErr error
+	for i, res := range r.minecraftClient.placeBlocks(ctx, world, requests) {
+		if res.Err != nil {
+			if placeErr == nil {
+				placeErr = res.Err
+			}
+
+			continue
+		}
+
+		placed[pending[i]] = blocks[pending[i]]
+		sent++
+	}
+
+	if placeErr != nil {
+		addClientError(diags, "place block", placeErr)
+		return
+	}
+
+	// restore the terrain for blocks that are no longer part of the shape
+	removed := []coordinate{}
+	for _, c := range sortedCoordinates(placed) {
+		if _, ok := desired[c]; !ok {
+			removed = append(removed, c)
+		}
+	}
+
+	var restoreErr error
+	for i, err := range r.restore(ctx, world, removed, placed) {
+		if err != nil {
+			if restoreErr == nil {
+				restoreErr = err
+			}
+
+			continue
+		}
+
+		delete(placed, removed[i])
+		sent++
+	}
+
+	if restoreErr != nil {
+		addClientError(diags, "restore block", restoreErr)
+		return
+	}
+
+	tflog.Debug(ctx, "placed shape", map[string]interface{}{
+		"blocks":  len(desired),
+		"changed": sent,
+	})
+}
+
+// restore places the blocks that were at coords in world before the shape
+// and returns the error of each block, in order. Restoring air to a block
+// that has already been removed in game is not an error.
+func (r *ShapeResource) restore(ctx context.Context, world string, coords []coordinate, placed map[coordinate]shapeBlock) []error {
+	requests := make([]blockRequest, len(coords))
+	for i, c := range coords {
+		b := placed[c]
+
+		requests[i] = blockRequest{X: c.X, Y: c.Y, Z: c.Z, Material: b.previousMaterial}
+		requests[i].setState(b.previousState)
+	}
+
+	errs := make([]error, len(coords))
+	for i, res := range r.minecraftClient.placeBlocks(ctx, world, requests) {
+		if !IsNotFound(res.Err) {
+			errs[i] = res.Err
+		}
+	}
+
+	return errs
+}
+
+// voxels returns the voxels from either the shape file or the inline
+// blocks, known is false when the source is not known until apply.
+func (m ShapeResourceModel) voxels(ctx context.Context) ([]voxel, bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if m.ShapeFile.IsUnknown() || m.Blocks.IsUnknown() {
+		return nil, false, diags
+	}
+
+	if !m.ShapeFile.IsNull() {
+		voxels, err := readVoxelFile(m.ShapeFile.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("shape_file"), "Unable to Read Shape File", err.Error())
+		}
+
+		// inline blocks are checked by the attribute validator
+		for _, v := range voxels {
+			if err := validateMaterial(v.Material); err != nil {
+				diags.AddAttributeError(
+					path.Root("shape_file"),
+					"Invalid Shape Material",
+					fmt.Sprintf("The block at %d,%d,%d in %s has an %s", v.X, v.Y, v.Z, m.ShapeFile.ValueString(), err),
+				)
+			}
+		}
+
+		return voxels, true, diags
+	}
+
+	blocks := []ShapeVoxelModel{}
+	diags.Append(m.Blocks.ElementsAs(ctx, &blocks, false)...)
+
+	voxels := make([]voxel, 0, len(blocks))
+	for _, b := range blocks {
+		if b.X.IsUnknown() || b.Y.IsUnknown() || b.Z.IsUnknown() || b.Material.IsUnknown() || b.Facing.IsUnknown() || b.Half.IsUnknown() {
+			return nil, false, diags
+		}
+
+		voxels = append(voxels, voxel{
+			X:        intValue(b.X),
+			Y:        intValue(b.Y),
+			Z:        intValue(b.Z),
+			Material: b.Material.ValueString(),
+			Facing:   b.Facing.ValueString(),
+			Half:     b.Half.ValueString(),
+		})
+	}
+
+	return voxels, true, diags
+}
+
+// worldBlocks converts the voxels to world coordinates using the origin and
+// rotation of the shape.
+func (m ShapeResourceModel) worldBlocks(voxels []voxel) (map[coordinate]shapeBlock, error) {
+	rotation := intValue(m.Rotation)
+	origin := coordinate{intValue(m.X), intValue(m.Y), intValue(m.Z)}
+
+	blocks := map[coordinate]shapeBlock{}
+	for _, v := range voxels {
+		if m.SkipAir.ValueBool() && v.Material == airMaterial {
+			continue
+		}
+
+		dx, dz, err := rotateOffset(v.X, v.Z, rotation)
+		if err != nil {
+			return nil, err
+		}
+
+		v.Facing = rotateFacing(v.Facing, rotation)
+
+		state := v.state()
+		if err := validateBlockState(state); err != nil {
+			return nil, fmt.Errorf("invalid block at %d,%d,%d: %s", v.X, v.Y, v.Z, err)
+		}
+
+		blocks[coordinate{origin.X + dx, origin.Y + v.Y, origin.Z + dz}] = shapeBlock{material: v.Material, state: state}
+	}
+
+	return blocks, nil
+}
+
+// id returns the identifier of the shape, named after its origin.
+func (m ShapeResourceModel) id() string {
+	return worldID(worldValue(m.World), fmt.Sprintf("%d,%d,%d", intValue(m.X), intValue(m.Y), intValue(m.Z)))
+}
+
+// originKnown returns true when the origin and rotation are known.
+func (m ShapeResourceModel) originKnown() bool {
+	return !m.X.IsUnknown() && !m.Y.IsUnknown() && !m.Z.IsUnknown() && !m.Rotation.IsUnknown()
+}
+
+func (m ShapeResourceModel) placedBlocks(ctx context.Context) (map[coordinate]shapeBlock, diag.Diagnostics) {
+	blocks := []ShapeBlockModel{}
+	diags := m.PlacedBlocks.ElementsAs(ctx, &blocks, false)
+
+	placed := map[coordinate]shapeBlock{}
+	for _, b := range blocks {
+		state, d := blockStateFromMap(ctx, b.State)
+		diags.Append(d...)
+
+		previousState, d := blockStateFromMap(ctx, b.PreviousState)
+		diags.Append(d...)
+
+		placed[coordinate{intValue(b.X), intValue(b.Y), intValue(b.Z)}] = shapeBlock{
+			material:         b.Material.ValueString(),
+			state:            state,
+			previousMaterial: b.PreviousMaterial.ValueString(),
+			previousState:    previousState,
+		}
+	}
+
+	return placed, diags
+}
+
+func (m *ShapeResourceModel) setPlacedBlocks(ctx context.Context, placed map[coordinate]shapeBlock) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	blocks := []attr.Value{}
+	for _, c := range sortedCoordinates(placed) {
+		b := placed[c]
+
+		state, d := types.MapValueFrom(ctx, types.StringType, b.state)
+		diags.Append(d...)
+
+		previousState, d := types.MapValueFrom(ctx, types.StringType, b.previousState)
+		diags.Append(d...)
+
+		blocks = append(blocks, types.ObjectValueMust(shapeBlockAttrTypes, map[string]attr.Value{
+			"x":                 numberValue(c.X),
+			"y":                 numberValue(c.Y),
+			"z":                 numberValue(c.Z),
+			"material":          types.StringValue(b.material),
+			"state":             state,
+			"previous_material": types.StringValue(b.previousMaterial),
+			"previous_state":    previousState,
+		}))
+	}
+
+	list, d := types.ListValue(types.ObjectType{AttrTypes: shapeBlockAttrTypes}, blocks)
+	diags.Append(d...)
+	m.PlacedBlocks = list
+
+	return diags
+}
+
+// hashVoxels returns a base64 encoded SHA-256 of the voxels.
+func hashVoxels(voxels []voxel, skipAir bool) string {
+	h := sha256.New()
+	json.NewEncoder(h).Encode(struct {
+		Voxels  []voxel `json:"voxels"`
+		SkipAir bool    `json:"skip_air"`
+	}{voxels, skipAir})
+
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
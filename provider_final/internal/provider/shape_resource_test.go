@@ -0,0 +1,136 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccShapeResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckFakeBlock(-1260, 24, 300, "minecraft:air"),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccShapeResourceInlineConfig(0, "minecraft:stone"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("minecraft_shape.steps", "placed_blocks.#", "2"),
+					resource.TestCheckResourceAttrSet("minecraft_shape.steps", "shape_hash"),
+					testAccCheckFakeBlock(-1260, 24, 300, "minecraft:stone"),
+					testAccCheckFakeBlock(-1260, 24, 301, "minecraft:oak_stairs"),
+				),
+			},
+			// Update testing, rotating moves the stairs and restores the terrain
+			{
+				Config: testAccShapeResourceInlineConfig(90, "minecraft:stone"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckFakeBlock(-1260, 24, 301, "minecraft:air"),
+					testAccCheckFakeBlock(-1261, 24, 300, "minecraft:oak_stairs"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccShapeResourceFile(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+  resource "minecraft_shape" "file" {
+	  x = -1250
+	  y = 23
+	  z = 300
+	  shape_file = "../../../jumppad/shape.json"
+	  skip_air = true
+	}
+  `,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("minecraft_shape.file", "placed_blocks.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestRotateFacing(t *testing.T) {
+	if f := rotateFacing("north", 90); f != "east" {
+		t.Fatalf("expected east, got %s", f)
+	}
+
+	if f := rotateFacing("west", 270); f != "south" {
+		t.Fatalf("expected south, got %s", f)
+	}
+
+	if f := rotateFacing("up", 180); f != "up" {
+		t.Fatalf("expected up, got %s", f)
+	}
+
+	// a block to the north of the origin ends up to the east
+	x, z, _ := rotateOffset(0, -1, 90)
+	if x != 1 || z != 0 {
+		t.Fatalf("expected 1,0 got %d,%d", x, z)
+	}
+}
+
+func TestMatchesShape(t *testing.T) {
+	desired := map[coordinate]shapeBlock{
+		{0, 0, 0}: {material: "minecraft:stone"},
+		{0, 0, 1}: {material: "minecraft:oak_stairs", state: map[string]string{"facing": "south"}},
+	}
+
+	placed := map[coordinate]shapeBlock{
+		{0, 0, 0}: {material: "minecraft:stone", previousMaterial: "minecraft:dirt"},
+		{0, 0, 1}: {material: "minecraft:oak_stairs", state: map[string]string{"facing": "south"}},
+	}
+
+	if !matchesShape(placed, desired) {
+		t.Fatal("expected the placed blocks to match the shape")
+	}
+
+	// a block changed in game is recorded by Read
+	placed[coordinate{0, 0, 0}] = shapeBlock{material: "minecraft:air", previousMaterial: "minecraft:dirt"}
+	if matchesShape(placed, desired) {
+		t.Fatal("expected a drifted block not to match the shape")
+	}
+
+	delete(placed, coordinate{0, 0, 0})
+	if matchesShape(placed, desired) {
+		t.Fatal("expected a missing block not to match the shape")
+	}
+}
+
+func TestShapeResourceModelID(t *testing.T) {
+	m := ShapeResourceModel{X: numberValue(-1), Y: numberValue(2), Z: numberValue(3), World: types.StringValue(defaultWorld)}
+
+	if got := m.id(); got != "-1,2,3" {
+		t.Fatalf("expected -1,2,3, got %s", got)
+	}
+
+	m.World = types.StringValue("minecraft:the_end")
+	if got := m.id(); got != "minecraft:the_end/-1,2,3" {
+		t.Fatalf("expected minecraft:the_end/-1,2,3, got %s", got)
+	}
+}
+
+func testAccShapeResourceInlineConfig(rotation int, material string) string {
+	return fmt.Sprintf(`
+  resource "minecraft_shape" "steps" {
+	  x = -1260
+	  y = 24
+	  z = 300
+	  rotation = %d
+
+	  blocks = [
+	    { x = 0, y = 0, z = 0, material = %q },
+	    { x = 0, y = 0, z = 1, material = "minecraft:oak_stairs", facing = "south", half = "bottom" },
+	  ]
+	}
+  `, rotation, material)
+}
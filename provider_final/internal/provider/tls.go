@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// defaultTLSMinVersion is the minimum TLS version used when the provider does
+// not set one.
+const defaultTLSMinVersion = "1.2"
+
+// tlsVersions maps the values of the provider tls_min_version attribute to
+// their TLS version.
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// tlsOptions configure the connection to https endpoints, certificates and
+// keys are PEM encoded.
+type tlsOptions struct {
+	InsecureSkipVerify bool
+	CACertPEM          string
+	ClientCertPEM      string
+	ClientKeyPEM       string
+	// MinVersion is one of the keys of tlsVersions, defaultTLSMinVersion is
+	// used when it is empty.
+	MinVersion string
+}
+
+// newTLSConfig returns the TLS configuration used to connect to https
+// endpoints. When CACertPEM is set the server certificate is verified against
+// it instead of the system roots, when the client certificate and key are set
+// they are presented to servers that request a client certificate.
+func newTLSConfig(opts tlsOptions) (*tls.Config, error) {
+	if opts.MinVersion == "" {
+		opts.MinVersion = defaultTLSMinVersion
+	}
+
+	version, ok := tlsVersions[opts.MinVersion]
+	if !ok {
+		return nil, fmt.Errorf("unknown tls_min_version %q, must be one of %s", opts.MinVersion, strings.Join(tlsVersionNames(), ", "))
+	}
+
+	config := &tls.Config{
+		InsecureSkipVerify: opts.InsecureSkipVerify,
+		MinVersion:         version,
+	}
+
+	if opts.CACertPEM != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(opts.CACertPEM)) {
+			return nil, errors.New("unable to parse the CA certificates, ca_cert_pem must contain one or more PEM encoded certificates")
+		}
+
+		config.RootCAs = pool
+	}
+
+	switch {
+	case opts.ClientCertPEM == "" && opts.ClientKeyPEM == "":
+	case opts.ClientCertPEM == "" || opts.ClientKeyPEM == "":
+		return nil, errors.New("a client certificate requires both client_cert_pem and client_key_pem, or the matching file attributes, to be set")
+	default:
+		cert, err := tls.X509KeyPair([]byte(opts.ClientCertPEM), []byte(opts.ClientKeyPEM))
+		if err != nil {
+			return nil, fmt.Errorf("unable to load the client certificate: %s", err)
+		}
+
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, nil
+}
+
+// tlsVersionNames returns the supported values of tls_min_version in order.
+func tlsVersionNames() []string {
+	names := make([]string, 0, len(tlsVersions))
+	for n := range tlsVersions {
+		names = append(names, n)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,225 @@
+package provider
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// testClientCertificate returns a self signed client certificate and its key,
+// PEM encoded.
+func testClientCertificate(t *testing.T) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "terraform"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+
+	return string(cert), string(keyPEM)
+}
+
+// newMutualTLSServer starts a server that requires a client certificate
+// issued by clientCA and returns the PEM encoded server certificate.
+func newMutualTLSServer(t *testing.T, clientCA string) (*httptest.Server, string) {
+	t.Helper()
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM([]byte(clientCA)) {
+		t.Fatal("unable to parse the client CA")
+	}
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":"1","x":0,"y":0,"z":0,"material":"minecraft:stone"}`))
+	}))
+	srv.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  pool,
+	}
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+
+	return srv, string(ca)
+}
+
+func TestTLSConfigClientCertificate(t *testing.T) {
+	cert, key := testClientCertificate(t)
+	srv, ca := newMutualTLSServer(t, cert)
+
+	opts := testClientOptions()
+	opts.MaxRetries = 0
+
+	tlsConfig, err := newTLSConfig(tlsOptions{CACertPEM: ca})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opts.TLSConfig = tlsConfig
+
+	c := newClient(srv.URL, "key", opts)
+	if _, err := c.getBlock(context.Background(), defaultWorld, 0, 0, 0); err == nil {
+		t.Fatal("expected the request without a client certificate to fail")
+	}
+
+	tlsConfig, err = newTLSConfig(tlsOptions{CACertPEM: ca, ClientCertPEM: cert, ClientKeyPEM: key})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opts.TLSConfig = tlsConfig
+
+	c = newClient(srv.URL, "key", opts)
+	if _, err := c.getBlock(context.Background(), defaultWorld, 0, 0, 0); err != nil {
+		t.Fatalf("expected the client certificate to be accepted, got: %s", err)
+	}
+}
+
+func TestTLSConfigMinVersion(t *testing.T) {
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":"1","x":0,"y":0,"z":0,"material":"minecraft:stone"}`))
+	}))
+	srv.TLS = &tls.Config{MaxVersion: tls.VersionTLS12}
+	srv.StartTLS()
+	defer srv.Close()
+
+	opts := testClientOptions()
+	opts.MaxRetries = 0
+
+	for version, ok := range map[string]bool{"1.2": true, "1.3": false} {
+		tlsConfig, err := newTLSConfig(tlsOptions{InsecureSkipVerify: true, MinVersion: version})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		opts.TLSConfig = tlsConfig
+
+		c := newClient(srv.URL, "key", opts)
+		_, err = c.getBlock(context.Background(), defaultWorld, 0, 0, 0)
+
+		if ok && err != nil {
+			t.Fatalf("expected TLS %s to connect, got: %s", version, err)
+		}
+
+		if !ok && err == nil {
+			t.Fatalf("expected TLS %s to be refused by a TLS 1.2 server", version)
+		}
+	}
+}
+
+func TestTLSConfigErrors(t *testing.T) {
+	cert, key := testClientCertificate(t)
+
+	tests := map[string]tlsOptions{
+		"unknown tls_min_version": {MinVersion: "1.4"},
+		"requires both":           {ClientCertPEM: cert},
+		"unable to load":          {ClientCertPEM: cert, ClientKeyPEM: cert},
+		"PEM encoded":             {CACertPEM: key},
+	}
+
+	for want, opts := range tests {
+		if _, err := newTLSConfig(opts); err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected an error containing %q, got: %v", want, err)
+		}
+	}
+}
+
+func TestProviderConfigTLSFiles(t *testing.T) {
+	clearProviderEnv(t)
+
+	cert, key := testClientCertificate(t)
+	srv, ca := newMutualTLSServer(t, cert)
+
+	dir := t.TempDir()
+	files := map[string]string{"ca.pem": ca, "cert.pem": cert, "key.pem": key}
+
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Setenv(envCACertFile, filepath.Join(dir, "ca.pem"))
+	t.Setenv(envClientKeyFile, filepath.Join(dir, "key.pem"))
+
+	var diags diag.Diagnostics
+	c := newProviderClient(MinecraftProviderModel{
+		Endpoint:       types.StringValue(srv.URL),
+		APIKey:         types.StringValue("key"),
+		ClientCertFile: types.StringValue(filepath.Join(dir, "cert.pem")),
+		TLSMinVersion:  types.StringValue("1.2"),
+	}, "test", &diags)
+	if diags.HasError() {
+		t.Fatalf("expected no errors, got: %v", diags)
+	}
+
+	if _, err := c.getBlock(context.Background(), defaultWorld, 0, 0, 0); err != nil {
+		t.Fatalf("expected the client certificate files to be used, got: %s", err)
+	}
+
+	// the inline certificate conflicts with the file
+	diags = nil
+	newProviderClient(MinecraftProviderModel{
+		Endpoint:       types.StringValue(srv.URL),
+		APIKey:         types.StringValue("key"),
+		ClientCertPEM:  types.StringValue(cert),
+		ClientCertFile: types.StringValue(filepath.Join(dir, "cert.pem")),
+	}, "test", &diags)
+
+	if !diags.HasError() || !strings.Contains(diags.Errors()[0].Detail(), "Only one of client_cert_pem and client_cert_file") {
+		t.Fatalf("expected a conflict error, got: %v", diags)
+	}
+
+	// a missing file is reported
+	diags = nil
+	t.Setenv(envCACertFile, filepath.Join(dir, "missing.pem"))
+	newProviderClient(MinecraftProviderModel{
+		Endpoint: types.StringValue(srv.URL),
+		APIKey:   types.StringValue("key"),
+	}, "test", &diags)
+
+	if !diags.HasError() || !strings.Contains(diags.Errors()[0].Detail(), "Unable to read ca_cert_file") {
+		t.Fatalf("expected a file error, got: %v", diags)
+	}
+}
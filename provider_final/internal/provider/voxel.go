@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// voxel is a single block in a shape or schema file, the coordinates are
+// relative to the origin the shape is placed at.
+type voxel struct {
+	X        int    `json:"x"`
+	Y        int    `json:"y"`
+	Z        int    `json:"z"`
+	Material string `json:"material"`
+	Facing   string `json:"facing"`
+	Half     string `json:"half"`
+}
+
+// state returns the block state properties of the voxel.
+func (v voxel) state() map[string]string {
+	state := map[string]string{}
+	if v.Facing != "" {
+		state["facing"] = v.Facing
+	}
+
+	if v.Half != "" {
+		state["half"] = v.Half
+	}
+
+	return state
+}
+
+// readVoxels decodes a JSON list of voxels such as jumppad/shape.json.
+func readVoxels(r io.Reader) ([]voxel, error) {
+	voxels := []voxel{}
+	if err := json.NewDecoder(r).Decode(&voxels); err != nil {
+		return nil, fmt.Errorf("unable to decode voxels: %s", err)
+	}
+
+	return voxels, nil
+}
+
+// readVoxelFile reads a JSON list of voxels from path.
+func readVoxelFile(path string) ([]voxel, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open shape file: %s", err)
+	}
+	defer f.Close()
+
+	return readVoxels(f)
+}
+
+// rotateOffset rotates the x, z offset clockwise around the origin, rotation
+// must be one of 0, 90, 180 or 270.
+func rotateOffset(x, z, rotation int) (int, int, error) {
+	switch rotation {
+	case 0:
+		return x, z, nil
+	case 90:
+		return -z, x, nil
+	case 180:
+		return -x, -z, nil
+	case 270:
+		return z, -x, nil
+	}
+
+	return 0, 0, fmt.Errorf("invalid rotation %d, must be one of 0, 90, 180, 270", rotation)
+}
+
+var facingClockwise = map[string]string{
+	"north": "east",
+	"east":  "south",
+	"south": "west",
+	"west":  "north",
+}
+
+// rotateFacing rotates a horizontal facing clockwise by rotation degrees,
+// up, down and empty values are returned unchanged.
+func rotateFacing(facing string, rotation int) string {
+	if _, ok := facingClockwise[facing]; !ok {
+		return facing
+	}
+
+	for i := 0; i < (rotation/90)%4; i++ {
+		facing = facingClockwise[facing]
+	}
+
+	return facing
+}
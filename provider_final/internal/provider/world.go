@@ -0,0 +1,186 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// defaultWorld is used when neither the provider nor the resource sets a
+// world, requests to it do not include the world so that servers which only
+// support the overworld are unaffected.
+const defaultWorld = "minecraft:overworld"
+
+// worldParameter is the query parameter that selects the world of a request.
+const worldParameter = "world"
+
+// worldHeader is the response header in which servers that support worlds
+// return the world a request was served in. Servers that predate worlds
+// ignore the world parameter and serve every request in the default world.
+const worldHeader = "X-Minecraft-World"
+
+// worldIDSeparator separates the world from the rest of an identifier.
+const worldIDSeparator = "/"
+
+// validateWorld returns an error when world is not a namespaced identifier
+// such as minecraft:the_nether.
+func validateWorld(world string) error {
+	if !identifierPattern.MatchString(world) || strings.Contains(world, worldIDSeparator) {
+		return fmt.Errorf("invalid world %q, must be a namespaced identifier such as minecraft:overworld, minecraft:the_nether or minecraft:the_end", world)
+	}
+
+	return nil
+}
+
+// worldValidator validates a string attribute containing a world.
+type worldValidator struct{}
+
+func (v worldValidator) Description(ctx context.Context) string {
+	return "world must be a namespaced identifier such as minecraft:the_nether"
+}
+
+func (v worldValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v worldValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if err := validateWorld(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid World", err.Error())
+	}
+}
+
+// worldValue returns the world in v, state written before worlds were
+// supported does not record one and is in the default world.
+func worldValue(v types.String) string {
+	if v.IsNull() || v.IsUnknown() || v.ValueString() == "" {
+		return defaultWorld
+	}
+
+	return v.ValueString()
+}
+
+// worldID prefixes id with the world so that the same coordinates in
+// different worlds have different identifiers, identifiers in the default
+// world are not prefixed.
+func worldID(world, id string) string {
+	if world == "" || world == defaultWorld {
+		return id
+	}
+
+	return world + worldIDSeparator + id
+}
+
+// splitWorldID returns the world and identifier of an id created by worldID,
+// the world is empty when id does not have one.
+func splitWorldID(id string) (string, string) {
+	i := strings.LastIndex(id, worldIDSeparator)
+	if i < 0 {
+		return "", id
+	}
+
+	return id[:i], id[i+1:]
+}
+
+// worldRoute adds the world to an API route.
+func worldRoute(world, route string) string {
+	if world == "" || world == defaultWorld {
+		return route
+	}
+
+	return route + "?" + url.Values{worldParameter: {world}}.Encode()
+}
+
+// checkWorld returns an error unless the server supports world. Requests that
+// change a world cannot be checked after they are sent, so the first one to
+// each world outside the default world is preceded by a read confirming the
+// world.
+func (c *client) checkWorld(ctx context.Context, world string) error {
+	if world == "" || world == defaultWorld {
+		return nil
+	}
+
+	if _, ok := c.worlds.Load(world); ok {
+		return nil
+	}
+
+	_, err := c.getBlock(ctx, world, 0, 0, 0)
+	return err
+}
+
+// confirmWorld returns an error unless the response header shows that the
+// server served a request to world in that world.
+func (c *client) confirmWorld(world string, header http.Header) error {
+	if world == "" || world == defaultWorld {
+		return nil
+	}
+
+	if got := header.Get(worldHeader); got != world {
+		return fmt.Errorf("the server does not support world %q, the %s response header was %q", world, worldHeader, got)
+	}
+
+	c.worlds.Store(world, true)
+
+	return nil
+}
+
+// planWorld plans the world of a resource, when it is not configured the
+// provider world is used. Moving a resource to another world replaces it.
+func planWorld(ctx context.Context, c *client, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var configured, planned, prior types.String
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("world"), &configured)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("world"), &planned)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the provider world is unknown until the provider is configured
+	if configured.IsNull() && c != nil {
+		planned = types.StringValue(c.world)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("world"), planned)...)
+	}
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("world"), &prior)...)
+
+	if planned.IsUnknown() || worldValue(planned) != worldValue(prior) {
+		resp.RequiresReplace.Append(path.Root("world"))
+	}
+}
+
+// resolveWorld returns the world in v, an unknown or unset world is the
+// provider world.
+func (c *client) resolveWorld(v types.String) types.String {
+	if v.IsNull() || v.IsUnknown() {
+		return types.StringValue(c.world)
+	}
+
+	return v
+}
+
+// importWorld returns the world of an imported resource, world is empty when
+// the import identifier does not include one. Identifiers without a world are
+// in the default world, as worldID does not prefix it, so that an imported
+// identifier matches the one the resource would have been given.
+func importWorld(world string) types.String {
+	if world == "" {
+		return types.StringValue(defaultWorld)
+	}
+
+	return types.StringValue(world)
+}
@@ -0,0 +1,65 @@
+package provider
+
+import "testing"
+
+func TestWorldID(t *testing.T) {
+	for _, tt := range []struct {
+		world, id, want string
+	}{
+		{"", "1,2,3", "1,2,3"},
+		{defaultWorld, "1,2,3", "1,2,3"},
+		{"minecraft:the_nether", "1,2,3", "minecraft:the_nether/1,2,3"},
+		{"minecraft:the_end", "undo-1", "minecraft:the_end/undo-1"},
+	} {
+		got := worldID(tt.world, tt.id)
+		if got != tt.want {
+			t.Fatalf("expected %q, got %q", tt.want, got)
+		}
+
+		world, id := splitWorldID(got)
+		if id != tt.id {
+			t.Fatalf("expected id %q from %q, got %q", tt.id, got, id)
+		}
+
+		if tt.world != defaultWorld && world != tt.world {
+			t.Fatalf("expected world %q from %q, got %q", tt.world, got, world)
+		}
+	}
+}
+
+func TestImportWorld(t *testing.T) {
+	// identifiers without a world round trip through worldID
+	world, _ := splitWorldID(worldID(defaultWorld, "1,2,3"))
+
+	if got := importWorld(world).ValueString(); got != defaultWorld {
+		t.Fatalf("expected an identifier without a world to be in %q, got %q", defaultWorld, got)
+	}
+
+	if got := importWorld("minecraft:the_nether").ValueString(); got != "minecraft:the_nether" {
+		t.Fatalf("expected minecraft:the_nether, got %q", got)
+	}
+}
+
+func TestWorldRoute(t *testing.T) {
+	if got := worldRoute(defaultWorld, "/v1/block"); got != "/v1/block" {
+		t.Fatalf("expected the default world to be omitted, got %q", got)
+	}
+
+	if got := worldRoute("minecraft:the_nether", "/v1/block"); got != "/v1/block?world=minecraft%3Athe_nether" {
+		t.Fatalf("unexpected route %q", got)
+	}
+}
+
+func TestValidateWorld(t *testing.T) {
+	for _, w := range []string{defaultWorld, "minecraft:the_nether", "multiverse:creative_1"} {
+		if err := validateWorld(w); err != nil {
+			t.Fatalf("expected %q to be valid: %s", w, err)
+		}
+	}
+
+	for _, w := range []string{"", "the_nether", "minecraft:The_End", "minecraft:a/b"} {
+		if err := validateWorld(w); err == nil {
+			t.Fatalf("expected %q to be invalid", w)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+// Package telemetry configures OpenTelemetry tracing for the provider. The
+// exporter is configured with the standard OTEL_EXPORTER_OTLP_* environment
+// variables, and spans are parented to the TRACEPARENT environment variable
+// when it is set so that provider spans join the trace of the process running
+// Terraform.
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName is the name of the tracer used for provider spans.
+const TracerName = "terraform-provider-minecraft"
+
+// batchTimeout is the batch timeout of the span processor, the provider
+// process can be stopped by Terraform at any time so spans are exported as
+// soon as possible.
+const batchTimeout = 100 * time.Millisecond
+
+// newSpanExporter returns an OTLP span exporter when an endpoint is set with
+// OTEL_EXPORTER_OTLP_ENDPOINT or OTEL_EXPORTER_OTLP_TRACES_ENDPOINT, it returns
+// nil when tracing is not configured. The exporters read the endpoint,
+// headers and TLS settings from the remaining OTEL_EXPORTER_OTLP_* variables.
+func newSpanExporter(ctx context.Context) (sdktrace.SpanExporter, error) {
+	if os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") == "" && os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") == "" {
+		return nil, nil
+	}
+
+	protocol := os.Getenv("OTEL_EXPORTER_OTLP_TRACES_PROTOCOL")
+	if protocol == "" {
+		protocol = os.Getenv("OTEL_EXPORTER_OTLP_PROTOCOL")
+	}
+
+	switch protocol {
+	case "", "http/protobuf":
+		return otlptracehttp.New(ctx)
+	case "grpc":
+		return otlptracegrpc.New(ctx)
+	default:
+		return nil, fmt.Errorf("unsupported OTLP protocol %q, must be http/protobuf or grpc", protocol)
+	}
+}
+
+var tracerProvider *sdktrace.TracerProvider
+
+// parent is the span context in TRACEPARENT, it is invalid when the variable
+// is not set.
+var parent trace.SpanContext
+
+// Init sets up the global OpenTelemetry tracer provider and propagator. Spans
+// are only exported when an exporter is configured with the OTEL_* environment
+// variables, otherwise the global no-op tracer provider is kept.
+func Init(ctx context.Context, version string) error {
+	if p, ok := os.LookupEnv("TRACEPARENT"); ok {
+		parent = trace.SpanContextFromContext(
+			propagation.TraceContext{}.Extract(ctx, propagation.MapCarrier{"traceparent": p}),
+		)
+	}
+
+	// Set up a text map propagator so that the trace context is sent to the
+	// Minecraft API. The default is a no-op.
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	exporter, err := newSpanExporter(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to configure tracing: %w", err)
+	}
+
+	if exporter == nil {
+		return nil
+	}
+
+	res, err := resource.Merge(
+		resource.Default(),
+		resource.NewSchemaless(
+			semconv.ServiceName(TracerName),
+			semconv.ServiceVersion(version),
+		),
+	)
+	if err != nil {
+		res = resource.Default()
+	}
+
+	tracerProvider = sdktrace.NewTracerProvider(
+		sdktrace.WithResource(res),
+		sdktrace.WithBatcher(exporter, sdktrace.WithBatchTimeout(batchTimeout)),
+	)
+
+	otel.SetTracerProvider(tracerProvider)
+
+	return nil
+}
+
+// Close flushes any remaining spans to the configured exporter and shuts down
+// the tracer provider.
+func Close() error {
+	if tracerProvider == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := tracerProvider.Shutdown(ctx); err != nil {
+		return fmt.Errorf("unable to shut down tracer provider: %w", err)
+	}
+
+	return nil
+}
+
+// Start starts a span using the provider tracer. When ctx does not contain a
+// span the span is parented to TRACEPARENT.
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	if !trace.SpanContextFromContext(ctx).IsValid() && parent.IsValid() {
+		ctx = trace.ContextWithRemoteSpanContext(ctx, parent)
+	}
+
+	return otel.Tracer(TracerName).Start(ctx, name, opts...)
+}
+
+// End ends the span, marking it as failed when fn returns an error. fn is
+// called when the span ends, so that it can be deferred with a function that
+// reads a named error result:
+//
+//	defer telemetry.End(span, func() error { return rerr })
+func End(span trace.Span, fn func() error) {
+	if err := fn(); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
@@ -0,0 +1,72 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestStartUsesTraceparent(t *testing.T) {
+	t.Setenv("TRACEPARENT", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	if err := Init(context.Background(), "test"); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { parent = trace.SpanContext{} }()
+
+	ctx, span := Start(context.Background(), "test")
+	defer span.End()
+
+	sc := trace.SpanContextFromContext(ctx)
+	if sc.TraceID().String() != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Fatalf("expected the span to join the TRACEPARENT trace, got: %s", sc.TraceID())
+	}
+
+	// spans with a parent in the context keep it
+	other := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{1},
+		TraceFlags: trace.FlagsSampled,
+	})
+
+	ctx, span = Start(trace.ContextWithSpanContext(context.Background(), other), "child")
+	defer span.End()
+
+	if got := trace.SpanContextFromContext(ctx).TraceID(); got != other.TraceID() {
+		t.Fatalf("expected the span to keep its parent, got: %s", got)
+	}
+}
+
+func TestNewSpanExporter(t *testing.T) {
+	ctx := context.Background()
+
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+
+	// tracing is disabled without an endpoint
+	exporter, err := newSpanExporter(ctx)
+	if err != nil || exporter != nil {
+		t.Fatalf("expected no exporter, got %v, %v", exporter, err)
+	}
+
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://localhost:4318")
+
+	for _, protocol := range []string{"", "http/protobuf", "grpc"} {
+		t.Setenv("OTEL_EXPORTER_OTLP_PROTOCOL", protocol)
+
+		exporter, err := newSpanExporter(ctx)
+		if err != nil || exporter == nil {
+			t.Fatalf("expected an exporter for protocol %q, got %v", protocol, err)
+		}
+
+		exporter.Shutdown(ctx)
+	}
+
+	// the traces protocol takes precedence
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_PROTOCOL", "http/json")
+
+	if _, err := newSpanExporter(ctx); err == nil {
+		t.Fatal("expected an error for an unsupported protocol")
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/provider"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/telemetry"
 )
 
 // Run "go generate" to format example terraform files and generate the docs for the registry/website
@@ -40,7 +41,18 @@ func main() {
 		Debug:   debug,
 	}
 
-	err := providerserver.Serve(context.Background(), provider.New(version), opts)
+	// Tracing is exported when configured with the OTEL_* environment
+	// variables, spans are flushed before the provider exits.
+	ctx := context.Background()
+	if err := telemetry.Init(ctx, version); err != nil {
+		log.Printf("[WARN] %s", err)
+	}
+
+	err := providerserver.Serve(ctx, provider.New(version), opts)
+
+	if err := telemetry.Close(); err != nil {
+		log.Printf("[ERROR] %s", err)
+	}
 
 	if err != nil {
 		log.Fatal(err.Error())